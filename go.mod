@@ -1,3 +1,5 @@
 module github.com/satori-protocol-go/satori-go
 
 go 1.25.4
+
+require golang.org/x/net v0.50.0
@@ -0,0 +1,72 @@
+package client
+
+import (
+	"context"
+
+	"github.com/satori-protocol-go/satori-go/pkg/satori/model/channel"
+	"github.com/satori-protocol-go/satori-go/pkg/satori/model/define"
+)
+
+type channelRequest struct {
+	ChannelId string           `json:"channel_id,omitempty"`
+	GuildId   string           `json:"guild_id,omitempty"`
+	UserId    string           `json:"user_id,omitempty"`
+	Next      string           `json:"next,omitempty"`
+	Data      *channel.Channel `json:"data,omitempty"`
+	Duration  *int64           `json:"duration,omitempty"`
+}
+
+// 获取频道
+func (c *Client) ChannelGet(ctx context.Context, channelId string) (*channel.Channel, error) {
+	var result channel.Channel
+	err := c.call(ctx, "channel.get", &channelRequest{ChannelId: channelId}, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// 获取群组频道列表
+func (c *Client) ChannelList(ctx context.Context, guildId, next string) (*define.Paginated[channel.Channel], error) {
+	var result define.Paginated[channel.Channel]
+	err := c.call(ctx, "channel.list", &channelRequest{GuildId: guildId, Next: next}, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// 创建群组频道
+func (c *Client) ChannelCreate(ctx context.Context, guildId string, data *channel.Channel) (*channel.Channel, error) {
+	var result channel.Channel
+	err := c.call(ctx, "channel.create", &channelRequest{GuildId: guildId, Data: data}, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// 修改群组频道
+func (c *Client) ChannelUpdate(ctx context.Context, channelId string, data *channel.Channel) error {
+	return c.call(ctx, "channel.update", &channelRequest{ChannelId: channelId, Data: data}, nil)
+}
+
+// 删除群组频道
+func (c *Client) ChannelDelete(ctx context.Context, channelId string) error {
+	return c.call(ctx, "channel.delete", &channelRequest{ChannelId: channelId}, nil)
+}
+
+// 禁言群组频道，duration 为禁言时长 (毫秒)，为 0 表示解除禁言
+func (c *Client) ChannelMute(ctx context.Context, channelId string, duration int64) error {
+	return c.call(ctx, "channel.mute", &channelRequest{ChannelId: channelId, Duration: &duration}, nil)
+}
+
+// 创建私聊频道，guildId 为空时不传递该参数
+func (c *Client) UserChannelCreate(ctx context.Context, userId, guildId string) (*channel.Channel, error) {
+	var result channel.Channel
+	err := c.call(ctx, "user.channel.create", &channelRequest{UserId: userId, GuildId: guildId}, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
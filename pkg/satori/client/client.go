@@ -0,0 +1,153 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+
+	"github.com/satori-protocol-go/satori-go/pkg/satori/model/define"
+)
+
+const (
+	HeaderPlatform = define.HeaderPlatform // 平台名称
+	HeaderUserId   = define.HeaderUserId   // 平台账号
+)
+
+// Option 客户端配置项
+type Option func(*Client)
+
+// 设置鉴权令牌
+func WithToken(token string) Option {
+	return func(c *Client) {
+		c.token = token
+	}
+}
+
+// 设置底层使用的 HTTP 客户端
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// 发送消息前自动上传 data: 协议的资源与 root 目录下 file:// 协议的文件，参见 Client.UploadResources。
+// 消息内容可能来自其他用户，root 之外的文件不会被读取，消息中包含这样的文件时发送失败；root 为空时不读取任何本地文件。
+func WithAutoUpload(root string) Option {
+	return func(c *Client) {
+		c.autoUpload = true
+		c.uploadRoot = root
+	}
+}
+
+// Client Satori HTTP API 客户端
+type Client struct {
+	endpoint   string
+	token      string
+	platform   string
+	userId     string
+	autoUpload bool
+	uploadRoot string
+	httpClient *http.Client
+	proxyUrls  *atomic.Pointer[[]string]
+}
+
+// 创建客户端，endpoint 为 Satori 服务的根地址，例如 http://127.0.0.1:5140
+func New(endpoint string, opts ...Option) *Client {
+	c := &Client{
+		endpoint:   strings.TrimRight(endpoint, "/"),
+		httpClient: http.DefaultClient,
+		proxyUrls:  new(atomic.Pointer[[]string]),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// 返回绑定到指定登录的客户端副本
+func (c *Client) WithLogin(platform, userId string) *Client {
+	clone := *c
+	clone.platform = platform
+	clone.userId = userId
+	return &clone
+}
+
+// 平台名称
+func (c *Client) Platform() string {
+	return c.platform
+}
+
+// 平台账号
+func (c *Client) UserId() string {
+	return c.userId
+}
+
+func (c *Client) url(method string) string {
+	return c.endpoint + "/v1/" + method
+}
+
+func (c *Client) header(req *http.Request) {
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	if c.platform != "" {
+		req.Header.Set(HeaderPlatform, c.platform)
+	}
+	if c.userId != "" {
+		req.Header.Set(HeaderUserId, c.userId)
+	}
+}
+
+func (c *Client) call(ctx context.Context, method string, body any, result any) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("satori: encode %s request: %w", method, err)
+	}
+	return c.do(ctx, method, "application/json", bytes.NewReader(payload), result)
+}
+
+func (c *Client) do(ctx context.Context, method, contentType string, body io.Reader, result any) error {
+	resp, err := c.send(ctx, method, contentType, body)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if result == nil || len(bytes.TrimSpace(data)) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(data, result); err != nil {
+		return fmt.Errorf("satori: decode %s response: %w", method, err)
+	}
+	return nil
+}
+
+// 发送请求，响应状态码不为 2XX 时返回 API 错误
+func (c *Client) send(ctx context.Context, method, contentType string, body io.Reader) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url(method), body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", contentType)
+	c.header(req)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		defer resp.Body.Close()
+		data, _ := io.ReadAll(resp.Body)
+		return nil, newAPIError(resp.StatusCode, method, c.platform, c.userId, data)
+	}
+	return resp, nil
+}
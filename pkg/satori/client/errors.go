@@ -0,0 +1,116 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// ErrAPI Satori API 返回了非 2xx 状态码。
+// 具体的状态码会被包装为 ErrBadRequest、ErrUnauthorized 等类型，它们都可以通过 errors.As 解包为 *ErrAPI。
+type ErrAPI struct {
+	StatusCode int    // HTTP 状态码
+	Method     string // API 方法名称
+	Platform   string // 平台名称
+	UserId     string // 平台账号
+	Body       []byte // 响应内容
+}
+
+func (e *ErrAPI) Error() string {
+	return fmt.Sprintf("satori: %s (platform %q, user %q): status %d: %s", e.Method, e.Platform, e.UserId, e.StatusCode, e.Body)
+}
+
+// HTTP 状态码，服务端据此原样返回适配器透传的 API 错误
+func (e *ErrAPI) HTTPStatus() int {
+	return e.StatusCode
+}
+
+// 是否为可以稍后重试的错误
+func (e *ErrAPI) Temporary() bool {
+	return e.StatusCode >= 500 || e.StatusCode == http.StatusTooManyRequests
+}
+
+// 400 请求格式错误
+type ErrBadRequest struct{ *ErrAPI }
+
+func (e *ErrBadRequest) Unwrap() error { return e.ErrAPI }
+
+func (e *ErrBadRequest) Is(target error) bool {
+	_, ok := target.(*ErrBadRequest)
+	return ok
+}
+
+// 401 缺失鉴权
+type ErrUnauthorized struct{ *ErrAPI }
+
+func (e *ErrUnauthorized) Unwrap() error { return e.ErrAPI }
+
+func (e *ErrUnauthorized) Is(target error) bool {
+	_, ok := target.(*ErrUnauthorized)
+	return ok
+}
+
+// 403 权限不足
+type ErrForbidden struct{ *ErrAPI }
+
+func (e *ErrForbidden) Unwrap() error { return e.ErrAPI }
+
+func (e *ErrForbidden) Is(target error) bool {
+	_, ok := target.(*ErrForbidden)
+	return ok
+}
+
+// 404 资源不存在
+type ErrNotFound struct{ *ErrAPI }
+
+func (e *ErrNotFound) Unwrap() error { return e.ErrAPI }
+
+func (e *ErrNotFound) Is(target error) bool {
+	_, ok := target.(*ErrNotFound)
+	return ok
+}
+
+// 405 请求方法不支持，通常表示平台未实现该 API
+type ErrMethodNotAllowed struct{ *ErrAPI }
+
+func (e *ErrMethodNotAllowed) Unwrap() error { return e.ErrAPI }
+
+func (e *ErrMethodNotAllowed) Is(target error) bool {
+	_, ok := target.(*ErrMethodNotAllowed)
+	return ok
+}
+
+// 5XX 服务器错误
+type ErrServerError struct{ *ErrAPI }
+
+func (e *ErrServerError) Unwrap() error { return e.ErrAPI }
+
+func (e *ErrServerError) Is(target error) bool {
+	_, ok := target.(*ErrServerError)
+	return ok
+}
+
+func newAPIError(statusCode int, method, platform, userId string, body []byte) error {
+	api := &ErrAPI{
+		StatusCode: statusCode,
+		Method:     method,
+		Platform:   platform,
+		UserId:     userId,
+		Body:       body,
+	}
+	switch {
+	case statusCode == http.StatusBadRequest:
+		return &ErrBadRequest{api}
+	case statusCode == http.StatusUnauthorized:
+		return &ErrUnauthorized{api}
+	case statusCode == http.StatusForbidden:
+		return &ErrForbidden{api}
+	case statusCode == http.StatusNotFound:
+		return &ErrNotFound{api}
+	case statusCode == http.StatusMethodNotAllowed:
+		return &ErrMethodNotAllowed{api}
+	case statusCode >= 500:
+		return &ErrServerError{api}
+	default:
+		return api
+	}
+}
@@ -0,0 +1,45 @@
+package client
+
+import (
+	"context"
+
+	"github.com/satori-protocol-go/satori-go/pkg/satori/model/define"
+	"github.com/satori-protocol-go/satori-go/pkg/satori/model/guild"
+)
+
+type guildRequest struct {
+	GuildId string `json:"guild_id,omitempty"`
+	Next    string `json:"next,omitempty"`
+}
+
+// 处理请求的参数，用于 guild.approve、guild.member.approve 与 friend.approve
+type approveRequest struct {
+	MessageId string `json:"message_id"`
+	Approve   bool   `json:"approve"`
+	Comment   string `json:"comment,omitempty"`
+}
+
+// 获取群组
+func (c *Client) GuildGet(ctx context.Context, guildId string) (*guild.Guild, error) {
+	var result guild.Guild
+	err := c.call(ctx, "guild.get", &guildRequest{GuildId: guildId}, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// 获取群组列表
+func (c *Client) GuildList(ctx context.Context, next string) (*define.Paginated[guild.Guild], error) {
+	var result define.Paginated[guild.Guild]
+	err := c.call(ctx, "guild.list", &guildRequest{Next: next}, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// 处理群组邀请，messageId 为 guild-request 事件中的请求 ID
+func (c *Client) GuildApprove(ctx context.Context, messageId string, approve bool, comment string) error {
+	return c.call(ctx, "guild.approve", &approveRequest{MessageId: messageId, Approve: approve, Comment: comment}, nil)
+}
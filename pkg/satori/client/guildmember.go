@@ -0,0 +1,62 @@
+package client
+
+import (
+	"context"
+
+	"github.com/satori-protocol-go/satori-go/pkg/satori/model/define"
+	"github.com/satori-protocol-go/satori-go/pkg/satori/model/guildmember"
+)
+
+type guildMemberRequest struct {
+	GuildId   string `json:"guild_id"`
+	UserId    string `json:"user_id,omitempty"`
+	RoleId    string `json:"role_id,omitempty"`
+	Next      string `json:"next,omitempty"`
+	Permanent bool   `json:"permanent,omitempty"`
+	Duration  *int64 `json:"duration,omitempty"`
+}
+
+// 获取群组成员
+func (c *Client) GuildMemberGet(ctx context.Context, guildId, userId string) (*guildmember.GuildMember, error) {
+	var result guildmember.GuildMember
+	err := c.call(ctx, "guild.member.get", &guildMemberRequest{GuildId: guildId, UserId: userId}, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// 获取群组成员列表
+func (c *Client) GuildMemberList(ctx context.Context, guildId, next string) (*define.Paginated[guildmember.GuildMember], error) {
+	var result define.Paginated[guildmember.GuildMember]
+	err := c.call(ctx, "guild.member.list", &guildMemberRequest{GuildId: guildId, Next: next}, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// 踢出群组成员，permanent 表示是否永久踢出 (无法再次加入群组)
+func (c *Client) GuildMemberKick(ctx context.Context, guildId, userId string, permanent bool) error {
+	return c.call(ctx, "guild.member.kick", &guildMemberRequest{GuildId: guildId, UserId: userId, Permanent: permanent}, nil)
+}
+
+// 禁言群组成员，duration 为禁言时长 (毫秒)，为 0 表示解除禁言
+func (c *Client) GuildMemberMute(ctx context.Context, guildId, userId string, duration int64) error {
+	return c.call(ctx, "guild.member.mute", &guildMemberRequest{GuildId: guildId, UserId: userId, Duration: &duration}, nil)
+}
+
+// 处理加群请求，messageId 为 guild-member-request 事件中的请求 ID
+func (c *Client) GuildMemberApprove(ctx context.Context, messageId string, approve bool, comment string) error {
+	return c.call(ctx, "guild.member.approve", &approveRequest{MessageId: messageId, Approve: approve, Comment: comment}, nil)
+}
+
+// 设置群组内用户的角色
+func (c *Client) GuildMemberRoleSet(ctx context.Context, guildId, userId, roleId string) error {
+	return c.call(ctx, "guild.member.role.set", &guildMemberRequest{GuildId: guildId, UserId: userId, RoleId: roleId}, nil)
+}
+
+// 取消群组内用户的角色
+func (c *Client) GuildMemberRoleUnset(ctx context.Context, guildId, userId, roleId string) error {
+	return c.call(ctx, "guild.member.role.unset", &guildMemberRequest{GuildId: guildId, UserId: userId, RoleId: roleId}, nil)
+}
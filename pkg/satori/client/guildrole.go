@@ -0,0 +1,45 @@
+package client
+
+import (
+	"context"
+
+	"github.com/satori-protocol-go/satori-go/pkg/satori/model/define"
+	"github.com/satori-protocol-go/satori-go/pkg/satori/model/guildrole"
+)
+
+type guildRoleRequest struct {
+	GuildId string               `json:"guild_id"`
+	RoleId  string               `json:"role_id,omitempty"`
+	Next    string               `json:"next,omitempty"`
+	Role    *guildrole.GuildRole `json:"role,omitempty"`
+}
+
+// 获取群组角色列表
+func (c *Client) GuildRoleList(ctx context.Context, guildId, next string) (*define.Paginated[guildrole.GuildRole], error) {
+	var result define.Paginated[guildrole.GuildRole]
+	err := c.call(ctx, "guild.role.list", &guildRoleRequest{GuildId: guildId, Next: next}, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// 创建群组角色
+func (c *Client) GuildRoleCreate(ctx context.Context, guildId string, role *guildrole.GuildRole) (*guildrole.GuildRole, error) {
+	var result guildrole.GuildRole
+	err := c.call(ctx, "guild.role.create", &guildRoleRequest{GuildId: guildId, Role: role}, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// 修改群组角色
+func (c *Client) GuildRoleUpdate(ctx context.Context, guildId, roleId string, role *guildrole.GuildRole) error {
+	return c.call(ctx, "guild.role.update", &guildRoleRequest{GuildId: guildId, RoleId: roleId, Role: role}, nil)
+}
+
+// 删除群组角色
+func (c *Client) GuildRoleDelete(ctx context.Context, guildId, roleId string) error {
+	return c.call(ctx, "guild.role.delete", &guildRoleRequest{GuildId: guildId, RoleId: roleId}, nil)
+}
@@ -0,0 +1,19 @@
+package client
+
+import (
+	"context"
+
+	"github.com/satori-protocol-go/satori-go/pkg/satori/model/event"
+)
+
+// Handler 事件处理器，WebSocket 与 Webhook 收到的事件都会交给它处理
+type Handler interface {
+	HandleEvent(ctx context.Context, e *event.Event)
+}
+
+// HandlerFunc 将普通函数适配为 Handler
+type HandlerFunc func(ctx context.Context, e *event.Event)
+
+func (f HandlerFunc) HandleEvent(ctx context.Context, e *event.Event) {
+	f(ctx, e)
+}
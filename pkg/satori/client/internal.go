@@ -0,0 +1,29 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Internal 调用平台内部 API，即 /v1/internal/{method}。
+// req 会被编码为 JSON 请求体，resp 不为 nil 时用于解码响应；如需保留原始响应，可传入 *json.RawMessage。
+func (c *Client) Internal(ctx context.Context, method string, req, resp any) error {
+	return c.call(ctx, "internal/"+method, req, resp)
+}
+
+// InternalStream 调用平台内部 API 并返回未读取的响应体，调用方负责关闭。
+// 适用于响应较大、需要使用 json.Decoder 流式解析的场景。
+func (c *Client) InternalStream(ctx context.Context, method string, req any) (io.ReadCloser, error) {
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("satori: encode internal/%s request: %w", method, err)
+	}
+	resp, err := c.send(ctx, "internal/"+method, "application/json", bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
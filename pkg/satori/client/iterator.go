@@ -0,0 +1,71 @@
+package client
+
+import (
+	"context"
+	"iter"
+
+	"github.com/satori-protocol-go/satori-go/pkg/satori/model/channel"
+	"github.com/satori-protocol-go/satori-go/pkg/satori/model/define"
+	"github.com/satori-protocol-go/satori-go/pkg/satori/model/guild"
+	"github.com/satori-protocol-go/satori-go/pkg/satori/model/guildmember"
+	"github.com/satori-protocol-go/satori-go/pkg/satori/model/guildrole"
+	"github.com/satori-protocol-go/satori-go/pkg/satori/model/message"
+	"github.com/satori-protocol-go/satori-go/pkg/satori/model/user"
+)
+
+// 逐项遍历群组频道，limit 大于 0 时最多产出 limit 项
+func (c *Client) ChannelIter(ctx context.Context, guildId string, limit int) iter.Seq2[channel.Channel, error] {
+	return define.All(ctx, limit, func(ctx context.Context, next string) (*define.Paginated[channel.Channel], error) {
+		return c.ChannelList(ctx, guildId, next)
+	})
+}
+
+// 逐项遍历群组
+func (c *Client) GuildIter(ctx context.Context, limit int) iter.Seq2[guild.Guild, error] {
+	return define.All(ctx, limit, c.GuildList)
+}
+
+// 逐项遍历群组成员
+func (c *Client) GuildMemberIter(ctx context.Context, guildId string, limit int) iter.Seq2[guildmember.GuildMember, error] {
+	return define.All(ctx, limit, func(ctx context.Context, next string) (*define.Paginated[guildmember.GuildMember], error) {
+		return c.GuildMemberList(ctx, guildId, next)
+	})
+}
+
+// 逐项遍历群组角色
+func (c *Client) GuildRoleIter(ctx context.Context, guildId string, limit int) iter.Seq2[guildrole.GuildRole, error] {
+	return define.All(ctx, limit, func(ctx context.Context, next string) (*define.Paginated[guildrole.GuildRole], error) {
+		return c.GuildRoleList(ctx, guildId, next)
+	})
+}
+
+// 逐项遍历添加特定表态的用户
+func (c *Client) ReactionIter(ctx context.Context, channelId, messageId, emoji string, limit int) iter.Seq2[user.User, error] {
+	return define.All(ctx, limit, func(ctx context.Context, next string) (*define.Paginated[user.User], error) {
+		return c.ReactionList(ctx, channelId, messageId, emoji, next)
+	})
+}
+
+// 逐项遍历好友
+func (c *Client) FriendIter(ctx context.Context, limit int) iter.Seq2[user.User, error] {
+	return define.All(ctx, limit, c.FriendList)
+}
+
+// 从 next 令牌处开始逐条遍历频道消息。
+// direction 为 DirectionBefore 时沿 prev 令牌向更早的消息遍历，否则沿 next 令牌向更新的消息遍历。
+func (c *Client) MessageIter(ctx context.Context, channelId, next string, direction Direction, limit int) iter.Seq2[message.Message, error] {
+	if direction == DirectionBefore {
+		return define.Backward(ctx, limit, func(ctx context.Context, token string) (*define.BidiPaginated[message.Message], error) {
+			if token == "" {
+				token = next
+			}
+			return c.MessageList(ctx, channelId, token, DirectionBefore, 0, "")
+		})
+	}
+	return define.Forward(ctx, limit, func(ctx context.Context, token string) (*define.BidiPaginated[message.Message], error) {
+		if token == "" {
+			token = next
+		}
+		return c.MessageList(ctx, channelId, token, DirectionAfter, 0, "")
+	})
+}
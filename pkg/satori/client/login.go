@@ -0,0 +1,17 @@
+package client
+
+import (
+	"context"
+
+	"github.com/satori-protocol-go/satori-go/pkg/satori/model/login"
+)
+
+// 获取当前登录信息
+func (c *Client) LoginGet(ctx context.Context) (*login.Login, error) {
+	var result login.Login
+	err := c.call(ctx, "login.get", struct{}{}, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
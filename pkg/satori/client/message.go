@@ -0,0 +1,95 @@
+package client
+
+import (
+	"context"
+
+	"github.com/satori-protocol-go/satori-go/pkg/satori/model/define"
+	"github.com/satori-protocol-go/satori-go/pkg/satori/model/message"
+)
+
+// Direction 双向分页的查询方向
+type Direction = define.Direction
+
+const (
+	DirectionBefore = define.DirectionBefore // 向前查询
+	DirectionAfter  = define.DirectionAfter  // 向后查询
+	DirectionAround = define.DirectionAround // 双向查询
+)
+
+// Order 分页结果的排序方式
+type Order = define.Order
+
+const (
+	OrderAsc  = define.OrderAsc  // 升序
+	OrderDesc = define.OrderDesc // 降序
+)
+
+type messageRequest struct {
+	ChannelId string    `json:"channel_id"`
+	MessageId string    `json:"message_id,omitempty"`
+	Content   string    `json:"content,omitempty"`
+	Next      string    `json:"next,omitempty"`
+	Direction Direction `json:"direction,omitempty"`
+	Limit     int       `json:"limit,omitempty"`
+	Order     Order     `json:"order,omitempty"`
+}
+
+// 发送消息
+func (c *Client) MessageCreate(ctx context.Context, channelId, content string) ([]*message.Message, error) {
+	if c.autoUpload {
+		uploaded, err := c.uploadContent(ctx, content)
+		if err != nil {
+			return nil, err
+		}
+		content = uploaded
+	}
+	var result []*message.Message
+	err := c.call(ctx, "message.create", &messageRequest{ChannelId: channelId, Content: content}, &result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// 获取消息
+func (c *Client) MessageGet(ctx context.Context, channelId, messageId string) (*message.Message, error) {
+	var result message.Message
+	err := c.call(ctx, "message.get", &messageRequest{ChannelId: channelId, MessageId: messageId}, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// 撤回消息
+func (c *Client) MessageDelete(ctx context.Context, channelId, messageId string) error {
+	return c.call(ctx, "message.delete", &messageRequest{ChannelId: channelId, MessageId: messageId}, nil)
+}
+
+// 编辑消息
+func (c *Client) MessageUpdate(ctx context.Context, channelId, messageId, content string) error {
+	if c.autoUpload {
+		uploaded, err := c.uploadContent(ctx, content)
+		if err != nil {
+			return err
+		}
+		content = uploaded
+	}
+	return c.call(ctx, "message.update", &messageRequest{ChannelId: channelId, MessageId: messageId, Content: content}, nil)
+}
+
+// 获取消息列表，direction、limit 与 order 为空时使用服务端默认值
+func (c *Client) MessageList(ctx context.Context, channelId, next string, direction Direction, limit int, order Order) (*define.BidiPaginated[message.Message], error) {
+	var result define.BidiPaginated[message.Message]
+	err := c.call(ctx, "message.list", &messageRequest{
+		ChannelId: channelId,
+		Next:      next,
+		Direction: direction,
+		Limit:     limit,
+		Order:     order,
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
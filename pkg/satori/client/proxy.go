@@ -0,0 +1,63 @@
+package client
+
+import (
+	"net/url"
+	"slices"
+
+	"github.com/satori-protocol-go/satori-go/pkg/satori/internal/proxyurl"
+	"github.com/satori-protocol-go/satori-go/pkg/satori/model/message/element"
+)
+
+// SetProxyUrls 设置服务端声明的代理路由前缀，通常在 READY 或 META 信令的回调中调用。
+// 通过 WithLogin 创建的副本共享同一份设置。
+func (c *Client) SetProxyUrls(urls []string) {
+	urls = slices.Clone(urls)
+	c.proxyUrls.Store(&urls)
+}
+
+// 服务端声明的代理路由前缀
+func (c *Client) ProxyUrls() []string {
+	if urls := c.proxyUrls.Load(); urls != nil {
+		return slices.Clone(*urls)
+	}
+	return nil
+}
+
+// ProxyURL 若 src 匹配代理路由前缀，返回经由 /v1/proxy/{url} 访问的地址，否则原样返回。
+// 与服务端相同，按解析后的 scheme、host 与清理后的路径匹配前缀
+func (c *Client) ProxyURL(src string) string {
+	urls := c.proxyUrls.Load()
+	if urls == nil {
+		return src
+	}
+	target, err := url.Parse(src)
+	if err != nil {
+		return src
+	}
+	for _, prefix := range *urls {
+		if base, ok := proxyurl.ParsePrefix(prefix); ok && proxyurl.Match(base, target) {
+			return c.url("proxy/" + src)
+		}
+	}
+	return src
+}
+
+// ProxyResources 将资源元素中匹配代理路由前缀的 src 改写为经由代理访问的地址
+func (c *Client) ProxyResources(elements ...element.Element) error {
+	resources := element.Select(elements, func(e element.Element) bool {
+		_, ok := e.(element.ResourceElement)
+		return ok
+	})
+	for _, e := range resources {
+		res := e.(element.ResourceElement).GetResource()
+		proxied := c.ProxyURL(res.Src)
+		if proxied == res.Src {
+			continue
+		}
+		res.Src = proxied
+		if err := e.Set("src", proxied); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package client
+
+import (
+	"context"
+
+	"github.com/satori-protocol-go/satori-go/pkg/satori/model/define"
+	"github.com/satori-protocol-go/satori-go/pkg/satori/model/user"
+)
+
+type reactionRequest struct {
+	ChannelId string `json:"channel_id"`
+	MessageId string `json:"message_id"`
+	Emoji     string `json:"emoji,omitempty"`
+	UserId    string `json:"user_id,omitempty"`
+	Next      string `json:"next,omitempty"`
+}
+
+// 添加表态
+func (c *Client) ReactionCreate(ctx context.Context, channelId, messageId, emoji string) error {
+	return c.call(ctx, "reaction.create", &reactionRequest{ChannelId: channelId, MessageId: messageId, Emoji: emoji}, nil)
+}
+
+// 删除表态，userId 为空时删除机器人自己的表态
+func (c *Client) ReactionDelete(ctx context.Context, channelId, messageId, emoji, userId string) error {
+	return c.call(ctx, "reaction.delete", &reactionRequest{ChannelId: channelId, MessageId: messageId, Emoji: emoji, UserId: userId}, nil)
+}
+
+// 清除表态，emoji 为空时清除全部表态
+func (c *Client) ReactionClear(ctx context.Context, channelId, messageId, emoji string) error {
+	return c.call(ctx, "reaction.clear", &reactionRequest{ChannelId: channelId, MessageId: messageId, Emoji: emoji}, nil)
+}
+
+// 获取添加特定表态的用户列表
+func (c *Client) ReactionList(ctx context.Context, channelId, messageId, emoji, next string) (*define.Paginated[user.User], error) {
+	var result define.Paginated[user.User]
+	err := c.call(ctx, "reaction.list", &reactionRequest{ChannelId: channelId, MessageId: messageId, Emoji: emoji, Next: next}, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
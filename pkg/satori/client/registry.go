@@ -0,0 +1,283 @@
+package client
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/satori-protocol-go/satori-go/pkg/satori/model/event"
+	"github.com/satori-protocol-go/satori-go/pkg/satori/model/login"
+)
+
+// LoginStatusHandler 登录状态变化的回调，from 与 to 分别为变化前后的状态
+type LoginStatusHandler func(ctx context.Context, l *login.Login, from, to login.LoginStatus)
+
+// 登录的唯一标识，序列号为 0 时使用平台名称与平台账号
+type loginKey struct {
+	sn       int64
+	platform string
+	userId   string
+}
+
+func keyOf(l *login.Login) loginKey {
+	if l.Sn != 0 {
+		return loginKey{sn: l.Sn}
+	}
+	return loginKey{platform: l.Platform, userId: loginUserId(l)}
+}
+
+// 平台名称与平台账号，用于在序列号缺失时找到同一个登录
+func accountOf(l *login.Login) loginKey {
+	return loginKey{platform: l.Platform, userId: loginUserId(l)}
+}
+
+func loginUserId(l *login.Login) string {
+	if l.User == nil {
+		return ""
+	}
+	return l.User.Id
+}
+
+// LoginRegistry 维护当前全部登录的状态，可以安全地并发使用。
+// 通过 Reset 使用 READY 信令中的登录列表初始化，并作为 Handler 接收 login-added、login-updated 与 login-removed 事件。
+// 新增的登录视为从离线状态变化而来，移除的登录视为变为离线状态。
+type LoginRegistry struct {
+	mu       sync.RWMutex
+	logins   loginIndex
+	handlers []LoginStatusHandler
+}
+
+// 创建登录注册表
+func NewLoginRegistry() *LoginRegistry {
+	return &LoginRegistry{logins: newLoginIndex()}
+}
+
+// 按登录标识保存的登录，以及平台名称与平台账号到登录标识的索引
+type loginIndex struct {
+	logins   map[loginKey]*login.Login
+	accounts map[loginKey]loginKey
+}
+
+func newLoginIndex() loginIndex {
+	return loginIndex{logins: map[loginKey]*login.Login{}, accounts: map[loginKey]loginKey{}}
+}
+
+// 查找与 l 对应的登录。
+// 序列号找不到时按平台名称与平台账号查找，使只有一方带有序列号的同一个登录能够合并
+func (x loginIndex) lookup(l *login.Login) (loginKey, *login.Login, bool) {
+	key := keyOf(l)
+	if prev, ok := x.logins[key]; ok {
+		return key, prev, true
+	}
+	account := accountOf(l)
+	if account.platform == "" || account.userId == "" {
+		return key, nil, false
+	}
+	if found, ok := x.accounts[account]; ok {
+		if prev := x.logins[found]; l.Sn == 0 || prev.Sn == 0 {
+			return found, prev, true
+		}
+	}
+	return key, nil, false
+}
+
+// 保存登录，同一账号有多个登录时索引指向序列号最小的一个
+func (x loginIndex) store(l *login.Login) {
+	key := keyOf(l)
+	x.logins[key] = l
+	account := accountOf(l)
+	if current, ok := x.accounts[account]; !ok || key.sn <= current.sn {
+		x.accounts[account] = key
+	}
+}
+
+func (x loginIndex) delete(key loginKey) {
+	l, ok := x.logins[key]
+	if !ok {
+		return
+	}
+	delete(x.logins, key)
+	account := accountOf(l)
+	if x.accounts[account] != key {
+		return
+	}
+	delete(x.accounts, account)
+	for other, candidate := range x.logins {
+		if accountOf(candidate) != account {
+			continue
+		}
+		if current, ok := x.accounts[account]; !ok || other.sn < current.sn {
+			x.accounts[account] = other
+		}
+	}
+}
+
+// 注册登录状态变化的回调，回调在触发变化的 goroutine 中按注册顺序执行
+func (r *LoginRegistry) OnStatusChange(fn LoginStatusHandler) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.handlers = append(r.handlers, fn)
+}
+
+type loginTransition struct {
+	login    *login.Login
+	from, to login.LoginStatus
+}
+
+// Reset 使用完整的登录列表替换当前状态，通常在 READY 信令的回调中调用
+func (r *LoginRegistry) Reset(ctx context.Context, logins []*login.Login) {
+	r.mu.Lock()
+	var transitions []loginTransition
+	next := newLoginIndex()
+	kept := map[loginKey]bool{}
+	for _, l := range logins {
+		if l == nil {
+			continue
+		}
+		l = cloneLogin(l)
+		next.store(l)
+		from := login.LoginStatusOffline
+		if key, prev, ok := r.logins.lookup(l); ok {
+			from = prev.Status
+			kept[key] = true
+		}
+		if from != l.Status {
+			transitions = append(transitions, loginTransition{l, from, l.Status})
+		}
+	}
+	for key, prev := range r.logins.logins {
+		if !kept[key] && prev.Status != login.LoginStatusOffline {
+			transitions = append(transitions, loginTransition{prev, prev.Status, login.LoginStatusOffline})
+		}
+	}
+	r.logins = next
+	handlers := r.handlers
+	r.mu.Unlock()
+	r.notify(ctx, handlers, transitions)
+}
+
+// HandleEvent 根据登录事件更新状态，其他事件会被忽略
+func (r *LoginRegistry) HandleEvent(ctx context.Context, e *event.Event) {
+	if e.Login == nil {
+		return
+	}
+	switch e.Type {
+	case event.EventTypeLoginAdded, event.EventTypeLoginUpdated:
+		r.update(ctx, e.Login)
+	case event.EventTypeLoginRemoved:
+		r.remove(ctx, e.Login)
+	}
+}
+
+func (r *LoginRegistry) update(ctx context.Context, l *login.Login) {
+	l = cloneLogin(l)
+	r.mu.Lock()
+	from := login.LoginStatusOffline
+	if key, prev, ok := r.logins.lookup(l); ok {
+		from = prev.Status
+		r.logins.delete(key)
+		// 更新事件可能只包含部分字段
+		if l.User == nil {
+			l.User = prev.User
+		}
+		if l.Platform == "" {
+			l.Platform = prev.Platform
+		}
+		if l.Adapter == "" {
+			l.Adapter = prev.Adapter
+		}
+		if l.Features == nil {
+			l.Features = prev.Features
+		}
+		if l.Sn == 0 {
+			l.Sn = prev.Sn
+		}
+	}
+	r.logins.store(l)
+	handlers := r.handlers
+	r.mu.Unlock()
+	if from != l.Status {
+		r.notify(ctx, handlers, []loginTransition{{l, from, l.Status}})
+	}
+}
+
+func (r *LoginRegistry) remove(ctx context.Context, l *login.Login) {
+	r.mu.Lock()
+	key, prev, ok := r.logins.lookup(l)
+	r.logins.delete(key)
+	handlers := r.handlers
+	r.mu.Unlock()
+	if ok && prev.Status != login.LoginStatusOffline {
+		r.notify(ctx, handlers, []loginTransition{{prev, prev.Status, login.LoginStatusOffline}})
+	}
+}
+
+func (r *LoginRegistry) notify(ctx context.Context, handlers []LoginStatusHandler, transitions []loginTransition) {
+	for _, t := range transitions {
+		for _, fn := range handlers {
+			fn(ctx, cloneLogin(t.login), t.from, t.to)
+		}
+	}
+}
+
+// 根据平台名称与平台账号查找登录，同一账号有多个登录时返回序列号最小的一个
+func (r *LoginRegistry) Get(platform, userId string) (*login.Login, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	key, ok := r.logins.accounts[loginKey{platform: platform, userId: userId}]
+	if !ok {
+		return nil, false
+	}
+	return cloneLogin(r.logins.logins[key]), true
+}
+
+// 根据序列号查找登录
+func (r *LoginRegistry) GetBySn(sn int64) (*login.Login, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	l, ok := r.logins.logins[loginKey{sn: sn}]
+	if !ok {
+		return nil, false
+	}
+	return cloneLogin(l), true
+}
+
+// 指定平台的全部登录，按序列号排序
+func (r *LoginRegistry) ByPlatform(platform string) []*login.Login {
+	return r.filter(func(l *login.Login) bool { return l.Platform == platform })
+}
+
+// 全部登录，按序列号排序
+func (r *LoginRegistry) List() []*login.Login {
+	return r.filter(func(*login.Login) bool { return true })
+}
+
+func (r *LoginRegistry) filter(match func(*login.Login) bool) []*login.Login {
+	r.mu.RLock()
+	result := make([]*login.Login, 0, len(r.logins.logins))
+	for _, l := range r.logins.logins {
+		if match(l) {
+			result = append(result, cloneLogin(l))
+		}
+	}
+	r.mu.RUnlock()
+	slices.SortFunc(result, func(a, b *login.Login) int {
+		return cmp.Or(
+			cmp.Compare(a.Sn, b.Sn),
+			cmp.Compare(a.Platform, b.Platform),
+			cmp.Compare(loginUserId(a), loginUserId(b)),
+		)
+	})
+	return result
+}
+
+func cloneLogin(l *login.Login) *login.Login {
+	clone := *l
+	if l.User != nil {
+		u := *l.User
+		clone.User = &u
+	}
+	clone.Features = slices.Clone(l.Features)
+	return &clone
+}
@@ -0,0 +1,267 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/satori-protocol-go/satori-go/pkg/satori/internal/xhtml"
+	"github.com/satori-protocol-go/satori-go/pkg/satori/model/define"
+	"github.com/satori-protocol-go/satori-go/pkg/satori/model/message/element"
+)
+
+// 待上传的文件
+type UploadFile = define.UploadFile
+
+// 上传文件，返回以 files 中的键为键的资源 URL
+func (c *Client) UploadCreate(ctx context.Context, files map[string]*UploadFile) (map[string]string, error) {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	for field, file := range files {
+		header := make(textproto.MIMEHeader)
+		header.Set("Content-Disposition", mime.FormatMediaType("form-data", map[string]string{
+			"name":     field,
+			"filename": file.Name,
+		}))
+		contentType := file.Type
+		if contentType == "" {
+			contentType = mime.TypeByExtension(filepath.Ext(file.Name))
+		}
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+		header.Set("Content-Type", contentType)
+		part, err := writer.CreatePart(header)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := io.Copy(part, file.Data); err != nil {
+			return nil, fmt.Errorf("satori: read upload file %q: %w", field, err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	result := map[string]string{}
+	err := c.do(ctx, "upload.create", writer.FormDataContentType(), &body, &result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// UploadResources 上传资源元素中的本地资源，并将其 src 改写为上传后的 URL。
+// 本地资源指 file:// 协议的文件与 data: 协议的内存数据，其他资源保持不变。
+// 这里读取的文件不受 WithAutoUpload 的 root 限制，不要传入来自其他用户的元素。
+func (c *Client) UploadResources(ctx context.Context, elements ...element.Element) error {
+	resources := element.Select(elements, func(e element.Element) bool {
+		res, ok := e.(element.ResourceElement)
+		return ok && isLocalResource(res.GetResource().Src)
+	})
+	sources := make([]string, 0, len(resources))
+	for _, e := range resources {
+		sources = append(sources, e.(element.ResourceElement).GetResource().Src)
+	}
+	uploaded, err := c.uploadSources(ctx, sources)
+	if err != nil {
+		return err
+	}
+	for _, e := range resources {
+		res := e.(element.ResourceElement).GetResource()
+		remote, ok := uploaded[res.Src]
+		if !ok {
+			continue
+		}
+		res.Src = remote
+		if err := e.Set("src", remote); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// 上传消息内容中的本地资源，未包含本地资源时原样返回
+func (c *Client) uploadContent(ctx context.Context, content string) (string, error) {
+	elements := xhtml.Parse(content, nil)
+	var (
+		resources []*xhtml.Element
+		sources   []string
+		err       error
+	)
+	var collect func(elements []*xhtml.Element)
+	collect = func(elements []*xhtml.Element) {
+		for _, e := range elements {
+			switch e.Tag() {
+			case "img", "image", "audio", "video", "file":
+				if src, ok := e.Attrs["src"].(string); ok && isLocalResource(src) {
+					if strings.HasPrefix(src, "file://") && err == nil {
+						err = checkUploadRoot(src, c.uploadRoot)
+					}
+					resources = append(resources, e)
+					sources = append(sources, src)
+				}
+			}
+			collect(e.Children)
+		}
+	}
+	collect(elements)
+	if err != nil {
+		return "", err
+	}
+	if len(resources) == 0 {
+		return content, nil
+	}
+
+	uploaded, err := c.uploadSources(ctx, sources)
+	if err != nil {
+		return "", err
+	}
+	for _, e := range resources {
+		if remote, ok := uploaded[e.Attrs["src"].(string)]; ok {
+			e.Attrs["src"] = remote
+		}
+	}
+	var builder strings.Builder
+	for _, e := range elements {
+		builder.WriteString(e.String())
+	}
+	return builder.String(), nil
+}
+
+// 上传本地资源，返回本地资源地址到远程 URL 的映射
+func (c *Client) uploadSources(ctx context.Context, sources []string) (map[string]string, error) {
+	files := make(map[string]*UploadFile, len(sources))
+	fields := make(map[string]string, len(sources))
+	for _, src := range sources {
+		if _, ok := fields[src]; ok {
+			continue
+		}
+		file, err := openLocalResource(src)
+		if err != nil {
+			return nil, err
+		}
+		field := "file" + strconv.Itoa(len(fields))
+		fields[src] = field
+		files[field] = file
+	}
+	if len(files) == 0 {
+		return nil, nil
+	}
+
+	urls, err := c.UploadCreate(ctx, files)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string]string, len(fields))
+	for src, field := range fields {
+		remote, ok := urls[field]
+		if !ok {
+			return nil, fmt.Errorf("satori: upload.create returned no url for %q", src)
+		}
+		result[src] = remote
+	}
+	return result, nil
+}
+
+func isLocalResource(src string) bool {
+	return strings.HasPrefix(src, "file://") || strings.HasPrefix(src, "data:")
+}
+
+// file:// 协议的文件路径，不支持带有主机名的地址
+func localPath(src string) (string, error) {
+	u, err := url.Parse(src)
+	if err != nil {
+		return "", err
+	}
+	if u.Host != "" {
+		return "", fmt.Errorf("satori: file url %q must not have a host", src)
+	}
+	return filepath.FromSlash(u.Path), nil
+}
+
+// 检查文件是否位于 root 目录下，符号链接会被解析后再比较
+func checkUploadRoot(src, root string) error {
+	path, err := localPath(src)
+	if err != nil {
+		return err
+	}
+	if root != "" {
+		if resolved, err := filepath.EvalSymlinks(path); err == nil {
+			path = resolved
+		}
+		if base, err := filepath.EvalSymlinks(root); err == nil {
+			root = base
+		}
+		path, _ = filepath.Abs(path)
+		root, _ = filepath.Abs(root)
+		if rel, err := filepath.Rel(root, path); err == nil && filepath.IsLocal(rel) {
+			return nil
+		}
+	}
+	return fmt.Errorf("satori: local file %q is outside the upload root", src)
+}
+
+func openLocalResource(src string) (*UploadFile, error) {
+	if rest, ok := strings.CutPrefix(src, "data:"); ok {
+		return parseDataURL(rest)
+	}
+	path, err := localPath(src)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	name := filepath.Base(path)
+	contentType := mime.TypeByExtension(filepath.Ext(name))
+	if contentType == "" {
+		contentType = http.DetectContentType(data)
+	}
+	return &UploadFile{Name: name, Type: contentType, Data: bytes.NewReader(data)}, nil
+}
+
+// 解析 data: 协议的内容，格式为 [<mediatype>][;base64],<data>
+func parseDataURL(rest string) (*UploadFile, error) {
+	meta, payload, ok := strings.Cut(rest, ",")
+	if !ok {
+		return nil, fmt.Errorf("satori: malformed data url")
+	}
+	contentType, isBase64 := strings.CutSuffix(meta, ";base64")
+	var data []byte
+	if isBase64 {
+		decoded, err := base64.StdEncoding.DecodeString(payload)
+		if err != nil {
+			return nil, fmt.Errorf("satori: malformed data url: %w", err)
+		}
+		data = decoded
+	} else {
+		decoded, err := url.PathUnescape(payload)
+		if err != nil {
+			return nil, fmt.Errorf("satori: malformed data url: %w", err)
+		}
+		data = []byte(decoded)
+	}
+	if contentType == "" {
+		contentType = http.DetectContentType(data)
+	}
+	name := "file"
+	if mediaType, _, err := mime.ParseMediaType(contentType); err == nil {
+		if exts, _ := mime.ExtensionsByType(mediaType); len(exts) > 0 {
+			name += exts[0]
+		}
+	}
+	return &UploadFile{Name: name, Type: contentType, Data: bytes.NewReader(data)}, nil
+}
@@ -0,0 +1,38 @@
+package client
+
+import (
+	"context"
+
+	"github.com/satori-protocol-go/satori-go/pkg/satori/model/define"
+	"github.com/satori-protocol-go/satori-go/pkg/satori/model/user"
+)
+
+type userRequest struct {
+	UserId string `json:"user_id,omitempty"`
+	Next   string `json:"next,omitempty"`
+}
+
+// 获取用户信息
+func (c *Client) UserGet(ctx context.Context, userId string) (*user.User, error) {
+	var result user.User
+	err := c.call(ctx, "user.get", &userRequest{UserId: userId}, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// 获取好友列表
+func (c *Client) FriendList(ctx context.Context, next string) (*define.Paginated[user.User], error) {
+	var result define.Paginated[user.User]
+	err := c.call(ctx, "friend.list", &userRequest{Next: next}, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// 处理好友申请，messageId 为 friend-request 事件中 message 的 ID
+func (c *Client) FriendApprove(ctx context.Context, messageId string, approve bool, comment string) error {
+	return c.call(ctx, "friend.approve", &approveRequest{MessageId: messageId, Approve: approve, Comment: comment}, nil)
+}
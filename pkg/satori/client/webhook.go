@@ -0,0 +1,79 @@
+package client
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/satori-protocol-go/satori-go/pkg/satori/model/event"
+	"github.com/satori-protocol-go/satori-go/pkg/satori/model/operation"
+)
+
+const (
+	HeaderOpcode = "Satori-OpCode" // Webhook 请求的信令类型
+
+	DefaultWebhookMaxBody = 16 << 20 // 默认 Webhook 请求体大小上限
+)
+
+// Webhook 以 HTTP POST 接收事件的 http.Handler。
+// 请求体为 EVENT 信令的 Body 数据，解码后交给与 WebSocket 客户端相同的 Handler 处理。
+type Webhook struct {
+	token   string
+	handler Handler
+	MaxBody int64 // 请求体大小上限 (字节)
+}
+
+// 创建 Webhook 接收器，token 为空时不校验鉴权
+func NewWebhook(token string, handler Handler) *Webhook {
+	return &Webhook{
+		token:   token,
+		handler: handler,
+		MaxBody: DefaultWebhookMaxBody,
+	}
+}
+
+func (h *Webhook) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if h.token != "" && subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), []byte("Bearer "+h.token)) != 1 {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	if opcode := r.Header.Get(HeaderOpcode); opcode != "" {
+		op, err := strconv.Atoi(opcode)
+		if err != nil {
+			http.Error(w, "invalid opcode", http.StatusBadRequest)
+			return
+		}
+		// 只处理 EVENT 信令，其余信令直接确认
+		if operation.Opcode(op) != operation.OpcodeEvent {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+	}
+
+	var body operation.EventBody
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, h.MaxBody))
+	if err := decoder.Decode(&body); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			http.Error(w, "request body too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+		http.Error(w, "malformed event: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if body.Type == "" {
+		http.Error(w, "malformed event: missing type", http.StatusBadRequest)
+		return
+	}
+	if h.handler != nil {
+		h.handler.HandleEvent(r.Context(), (*event.Event)(&body))
+	}
+	w.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,253 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/satori-protocol-go/satori-go/pkg/satori/model/event"
+	"github.com/satori-protocol-go/satori-go/pkg/satori/model/operation"
+	"golang.org/x/net/websocket"
+)
+
+const (
+	DefaultPingInterval = 10 * time.Second // 默认心跳间隔
+	DefaultMinBackoff   = time.Second      // 默认首次重连等待时间
+	DefaultMaxBackoff   = time.Minute      // 默认最长重连等待时间
+)
+
+// WebSocketOption WebSocket 客户端配置项
+type WebSocketOption func(*WebSocket)
+
+// 设置心跳间隔，不大于 0 时使用 DefaultPingInterval
+func WithPingInterval(interval time.Duration) WebSocketOption {
+	return func(w *WebSocket) {
+		if interval <= 0 {
+			interval = DefaultPingInterval
+		}
+		w.pingInterval = interval
+	}
+}
+
+// 设置重连等待时间的范围，每次重连失败后等待时间翻倍，直到 max。
+// 不大于 0 的值使用 DefaultMinBackoff 与 DefaultMaxBackoff，max 小于 min 时使用 min
+func WithBackoff(min, max time.Duration) WebSocketOption {
+	return func(w *WebSocket) {
+		if min <= 0 {
+			min = DefaultMinBackoff
+		}
+		if max <= 0 {
+			max = DefaultMaxBackoff
+		}
+		if max < min {
+			max = min
+		}
+		w.minBackoff = min
+		w.maxBackoff = max
+	}
+}
+
+// 设置首次鉴权时使用的序列号，用于从上次的会话恢复
+func WithSn(sn int64) WebSocketOption {
+	return func(w *WebSocket) {
+		w.sn.Store(sn)
+	}
+}
+
+// 设置 READY 信令的回调
+func WithReadyHandler(fn func(ctx context.Context, body *operation.ReadyBody)) WebSocketOption {
+	return func(w *WebSocket) {
+		w.onReady = fn
+	}
+}
+
+// 设置 META 信令的回调
+func WithMetaHandler(fn func(ctx context.Context, body *operation.MetaBody)) WebSocketOption {
+	return func(w *WebSocket) {
+		w.onMeta = fn
+	}
+}
+
+// 设置需要重新同步的回调。
+// 恢复会话后收到的第一个事件的序列号与上次收到的不连续时，说明服务端已无法补发遗漏的事件，客户端应重新获取完整状态。
+// 服务端不会主动通知需要重新同步，恢复会话后收到新的事件之前不会调用该回调。
+func WithResyncHandler(fn func(ctx context.Context)) WebSocketOption {
+	return func(w *WebSocket) {
+		w.onResync = fn
+	}
+}
+
+// 设置连接错误的回调，错误发生后客户端会自动重连
+func WithErrorHandler(fn func(err error)) WebSocketOption {
+	return func(w *WebSocket) {
+		w.onError = fn
+	}
+}
+
+// WebSocket 通过 /v1/events 接收事件的客户端。
+// 连接断开后会按指数退避自动重连，并使用最后收到的序列号恢复会话。
+type WebSocket struct {
+	endpoint     string
+	token        string
+	handler      Handler
+	sn           atomic.Int64
+	pingInterval time.Duration
+	minBackoff   time.Duration
+	maxBackoff   time.Duration
+	onReady      func(ctx context.Context, body *operation.ReadyBody)
+	onMeta       func(ctx context.Context, body *operation.MetaBody)
+	onResync     func(ctx context.Context)
+	onError      func(err error)
+}
+
+// 创建 WebSocket 客户端，endpoint 与 New 相同，为 Satori 服务的根地址
+func NewWebSocket(endpoint, token string, handler Handler, opts ...WebSocketOption) *WebSocket {
+	w := &WebSocket{
+		endpoint:     strings.TrimRight(endpoint, "/"),
+		token:        token,
+		handler:      handler,
+		pingInterval: DefaultPingInterval,
+		minBackoff:   DefaultMinBackoff,
+		maxBackoff:   DefaultMaxBackoff,
+	}
+	for _, opt := range opts {
+		opt(w)
+	}
+	return w
+}
+
+// 最后收到的事件序列号
+func (w *WebSocket) Sn() int64 {
+	return w.sn.Load()
+}
+
+// Run 连接并持续接收事件，直到 ctx 被取消。
+// 事件按接收顺序在同一个 goroutine 中交给 Handler 处理。
+func (w *WebSocket) Run(ctx context.Context) error {
+	backoff := w.minBackoff
+	for {
+		ready, err := w.session(ctx)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err != nil && w.onError != nil {
+			w.onError(err)
+		}
+		if ready {
+			backoff = w.minBackoff
+		}
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+		backoff = min(backoff*2, w.maxBackoff)
+	}
+}
+
+func (w *WebSocket) url() (string, string) {
+	origin := w.endpoint
+	location := origin
+	switch {
+	case strings.HasPrefix(location, "https://"):
+		location = "wss://" + strings.TrimPrefix(location, "https://")
+	case strings.HasPrefix(location, "http://"):
+		location = "ws://" + strings.TrimPrefix(location, "http://")
+	}
+	return location + "/v1/events", origin
+}
+
+// 建立一次连接，返回是否收到过 READY 信令
+func (w *WebSocket) session(ctx context.Context) (bool, error) {
+	location, origin := w.url()
+	config, err := websocket.NewConfig(location, origin)
+	if err != nil {
+		return false, err
+	}
+	conn, err := config.DialContext(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close()
+
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			conn.Close()
+		case <-done:
+		}
+	}()
+
+	resumed := w.sn.Load()
+	identify := &operation.Operation{
+		Op:   operation.OpcodeIdentify,
+		Body: &operation.IdentifyBody{Token: w.token, Sn: resumed},
+	}
+	if err := websocket.JSON.Send(conn, identify); err != nil {
+		return false, fmt.Errorf("satori: send identify: %w", err)
+	}
+	go w.ping(conn, done)
+
+	ready := false
+	for {
+		if err := conn.SetReadDeadline(time.Now().Add(3 * w.pingInterval)); err != nil {
+			return ready, err
+		}
+		var op operation.Operation
+		if err := websocket.JSON.Receive(conn, &op); err != nil {
+			return ready, fmt.Errorf("satori: receive operation: %w", err)
+		}
+		switch body := op.Body.(type) {
+		case *operation.EventBody:
+			e := (*event.Event)(body)
+			if resumed > 0 && e.Sn > 0 {
+				if e.Sn != resumed+1 && w.onResync != nil {
+					w.onResync(ctx)
+				}
+				resumed = 0
+			}
+			// 服务端重启或事件日志重置后序列号会重新开始，直接使用收到的序列号
+			if e.Sn > 0 {
+				w.sn.Store(e.Sn)
+			}
+			if w.handler != nil {
+				w.handler.HandleEvent(ctx, e)
+			}
+		case *operation.ReadyBody:
+			ready = true
+			if w.onReady != nil {
+				w.onReady(ctx, body)
+			}
+		case *operation.MetaBody:
+			if w.onMeta != nil {
+				w.onMeta(ctx, body)
+			}
+		default:
+			if op.Op == operation.OpcodeReady {
+				ready = true
+			}
+		}
+	}
+}
+
+func (w *WebSocket) ping(conn *websocket.Conn, done <-chan struct{}) {
+	ticker := time.NewTicker(w.pingInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			if err := websocket.JSON.Send(conn, &operation.Operation{Op: operation.OpcodePing}); err != nil {
+				conn.Close()
+				return
+			}
+		}
+	}
+}
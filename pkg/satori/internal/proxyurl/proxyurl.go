@@ -0,0 +1,37 @@
+package proxyurl
+
+import (
+	"net/url"
+	"path"
+	"slices"
+	"strings"
+)
+
+// ParsePrefix 解析代理路由前缀，前缀必须是带 scheme 与 host 且不带 userinfo 的绝对 URL
+func ParsePrefix(prefix string) (*url.URL, bool) {
+	base, err := url.Parse(prefix)
+	if err != nil || base.Scheme == "" || base.Host == "" || base.User != nil {
+		return nil, false
+	}
+	return base, true
+}
+
+// Match 按解析后的 URL 匹配：scheme 与 host 必须完全一致且不允许携带 userinfo，解码并清理后的路径按前缀匹配
+func Match(base, target *url.URL) bool {
+	if base == nil || target.User != nil {
+		return false
+	}
+	if !strings.EqualFold(base.Scheme, target.Scheme) || !strings.EqualFold(base.Host, target.Host) {
+		return false
+	}
+	// 解码后的路径不允许包含 .. 段或编码的 /，避免越过前缀访问其他路径
+	if strings.Contains(strings.ToLower(target.EscapedPath()), "%2f") ||
+		slices.Contains(strings.Split(target.Path, "/"), "..") {
+		return false
+	}
+	clean := path.Clean("/" + target.Path)
+	if strings.HasSuffix(target.Path, "/") && clean != "/" {
+		clean += "/"
+	}
+	return strings.HasPrefix(clean, base.Path)
+}
@@ -0,0 +1,6 @@
+package define
+
+const (
+	HeaderPlatform = "Satori-Platform" // 平台名称
+	HeaderUserId   = "Satori-User-ID"  // 平台账号
+)
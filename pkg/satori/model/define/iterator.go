@@ -0,0 +1,84 @@
+package define
+
+import (
+	"context"
+	"iter"
+	"slices"
+)
+
+// 分页列表的拉取函数，next 为空时拉取第一页
+type PageFetcher[T any] func(ctx context.Context, next string) (*Paginated[T], error)
+
+// 双向分页列表的拉取函数，token 为空时拉取初始页
+type BidiPageFetcher[T any] func(ctx context.Context, token string) (*BidiPaginated[T], error)
+
+// All 沿 next 令牌依次拉取分页列表并逐项产出。
+// limit 大于 0 时最多产出 limit 项；拉取失败或 ctx 被取消时产出错误并结束。
+func All[T any](ctx context.Context, limit int, fetch PageFetcher[T]) iter.Seq2[T, error] {
+	return walk(ctx, limit, false, func(ctx context.Context, token string) ([]T, string, error) {
+		page, err := fetch(ctx, token)
+		if err != nil || page == nil {
+			return nil, "", err
+		}
+		return page.Data, page.Next, nil
+	})
+}
+
+// Forward 沿 next 令牌向后遍历双向分页列表，页内按原顺序产出。
+func Forward[T any](ctx context.Context, limit int, fetch BidiPageFetcher[T]) iter.Seq2[T, error] {
+	return walk(ctx, limit, false, func(ctx context.Context, token string) ([]T, string, error) {
+		page, err := fetch(ctx, token)
+		if err != nil || page == nil {
+			return nil, "", err
+		}
+		return page.Data, page.Next, nil
+	})
+}
+
+// Backward 沿 prev 令牌向前遍历双向分页列表，页内按逆序产出。
+func Backward[T any](ctx context.Context, limit int, fetch BidiPageFetcher[T]) iter.Seq2[T, error] {
+	return walk(ctx, limit, true, func(ctx context.Context, token string) ([]T, string, error) {
+		page, err := fetch(ctx, token)
+		if err != nil || page == nil {
+			return nil, "", err
+		}
+		return page.Data, page.Prev, nil
+	})
+}
+
+func walk[T any](ctx context.Context, limit int, reverse bool, fetch func(ctx context.Context, token string) ([]T, string, error)) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		count := 0
+		token := ""
+		for {
+			if err := ctx.Err(); err != nil {
+				yield(zero, err)
+				return
+			}
+			data, next, err := fetch(ctx, token)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			if reverse {
+				data = slices.Clone(data)
+				slices.Reverse(data)
+			}
+			for _, item := range data {
+				if limit > 0 && count >= limit {
+					return
+				}
+				if !yield(item, nil) {
+					return
+				}
+				count++
+			}
+			// 已达到数量上限、令牌为空或未前进时视为已到达末页
+			if (limit > 0 && count >= limit) || next == "" || next == token {
+				return
+			}
+			token = next
+		}
+	}
+}
@@ -12,3 +12,20 @@ type BidiPaginated[T any] struct {
 	Prev string `json:"prev,omitempty"` // 上一页的令牌
 	Next string `json:"next,omitempty"` // 下一页的令牌
 }
+
+// Direction 双向分页的查询方向
+type Direction string
+
+const (
+	DirectionBefore Direction = "before" // 向前查询
+	DirectionAfter  Direction = "after"  // 向后查询
+	DirectionAround Direction = "around" // 双向查询
+)
+
+// Order 分页结果的排序方式
+type Order string
+
+const (
+	OrderAsc  Order = "asc"  // 升序
+	OrderDesc Order = "desc" // 降序
+)
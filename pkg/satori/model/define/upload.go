@@ -0,0 +1,10 @@
+package define
+
+import "io"
+
+// 待上传的文件
+type UploadFile struct {
+	Name string    // 文件名称
+	Type string    // MIME 类型，为空时根据文件名称推断
+	Data io.Reader // 文件内容
+}
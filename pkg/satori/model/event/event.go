@@ -8,6 +8,7 @@ import (
 	"github.com/satori-protocol-go/satori-go/pkg/satori/model/interaction"
 	"github.com/satori-protocol-go/satori-go/pkg/satori/model/login"
 	"github.com/satori-protocol-go/satori-go/pkg/satori/model/message"
+	"github.com/satori-protocol-go/satori-go/pkg/satori/model/reaction"
 	"github.com/satori-protocol-go/satori-go/pkg/satori/model/user"
 )
 
@@ -78,6 +79,7 @@ type Event struct {
 	Guild     *guild.Guild             `json:"guild,omitempty"`    // 事件所属的群组
 	Member    *guildmember.GuildMember `json:"member,omitempty"`   // 事件的目标成员
 	Message   *message.Message         `json:"message,omitempty"`  // 事件的消息
+	Emoji     *reaction.Emoji          `json:"emoji,omitempty"`    // 事件的表情
 	Operator  *user.User               `json:"operator,omitempty"` // 事件的操作者
 	Role      *guildrole.GuildRole     `json:"role,omitempty"`     // 事件的目标角色
 	User      *user.User               `json:"user,omitempty"`     // 事件的目标用户
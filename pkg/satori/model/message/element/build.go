@@ -0,0 +1,109 @@
+package element
+
+import "strings"
+
+// 构造元素，属性均为合法值，不会失败
+func build[T Element](attrs map[string]any, children ...Element) T {
+	element, err := New[T](attrs)
+	if err != nil {
+		panic(err)
+	}
+	element.AddChild(children...)
+	return element
+}
+
+// Plain 构造一段纯文本
+func Plain(text string) *Text {
+	return build[*Text](map[string]any{"text": text})
+}
+
+// AtUser 构造提及指定用户的 <at> 元素
+func AtUser(id string) *At {
+	return build[*At](map[string]any{"id": id})
+}
+
+// AtRole 构造提及指定角色的 <at> 元素
+func AtRole(role string) *At {
+	return build[*At](map[string]any{"role": role})
+}
+
+// AtAll 构造提及全体成员的 <at> 元素
+func AtAll() *At {
+	return build[*At](map[string]any{"type": "all"})
+}
+
+// AtHere 构造提及在线成员的 <at> 元素
+func AtHere() *At {
+	return build[*At](map[string]any{"type": "here"})
+}
+
+// Channel 构造提及指定频道的 <sharp> 元素
+func Channel(id string) *Sharp {
+	return build[*Sharp](map[string]any{"id": id})
+}
+
+// Link 构造链接，没有子元素时平台通常会直接显示链接地址
+func Link(href string, children ...Element) *A {
+	return build[*A](map[string]any{"href": href}, children...)
+}
+
+// Image 构造图片
+func Image(src string) *Img {
+	return build[*Img](map[string]any{"src": src})
+}
+
+// Bold 构造粗体
+func Bold(children ...Element) *Strong {
+	return build[*Strong](nil, children...)
+}
+
+// Italic 构造斜体
+func Italic(children ...Element) *Em {
+	return build[*Em](nil, children...)
+}
+
+// Underline 构造下划线
+func Underline(children ...Element) *Ins {
+	return build[*Ins](nil, children...)
+}
+
+// Strike 构造删除线
+func Strike(children ...Element) *Del {
+	return build[*Del](nil, children...)
+}
+
+// Spoiler 构造剧透
+func Spoiler(children ...Element) *Spl {
+	return build[*Spl](nil, children...)
+}
+
+// Monospace 构造等宽文本
+func Monospace(children ...Element) *Code {
+	return build[*Code](nil, children...)
+}
+
+// Paragraph 构造段落
+func Paragraph(children ...Element) *P {
+	return build[*P](nil, children...)
+}
+
+// Newline 构造换行
+func Newline() *Br {
+	return build[*Br](nil)
+}
+
+// Reply 构造引用指定消息的 <quote> 元素
+func Reply(id string) *Quote {
+	return build[*Quote](map[string]any{"id": id})
+}
+
+// Marshal 将元素序列化为可以直接用于 message.create 的消息内容
+func Marshal(elements ...Element) string {
+	var builder strings.Builder
+	for _, e := range elements {
+		if e != nil {
+			builder.WriteString(e.MarshalXHTML(false))
+		}
+	}
+	return builder.String()
+}
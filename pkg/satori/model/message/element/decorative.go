@@ -4,6 +4,7 @@ package element
 //
 // 修饰元素用于修饰其中的内容。
 // 如果对应的平台不支持对应的元素，可以忽略这个元素本身，正常渲染其中的子元素。
+// 子元素可以是提及、链接、换行等任意元素。
 type Decorative struct {
 	BaseElement
 }
@@ -14,35 +15,6 @@ type decorativeElement interface {
 
 func (d *Decorative) isDecorative() {}
 
-func (d *Decorative) AddChild(content ...Element) {
-	if d == nil {
-		return
-	}
-	for _, c := range content {
-		bindOwner(c)
-		if _, ok := c.(*Text); ok {
-			d.children = append(d.children, c)
-			continue
-		}
-		if _, ok := c.(decorativeElement); ok {
-			d.children = append(d.children, c)
-		}
-	}
-}
-
-func (d *Decorative) AddChildString(content ...string) {
-	if d == nil {
-		return
-	}
-	for _, c := range content {
-		text, err := New[*Text](map[string]any{"text": c})
-		if err != nil {
-			continue
-		}
-		d.children = append(d.children, text)
-	}
-}
-
 // <b> 或 <strong> 元素用于将其中的内容以粗体显示。
 type Strong struct {
 	Decorative
@@ -0,0 +1,91 @@
+package element
+
+// 元素相关的平台特性，出现在 login.Login.Features 中时表示平台支持对应的元素，参见 Downgrade
+const (
+	FeatureButton = "message.element.button"  // 按钮
+	FeatureAudio  = "message.element.audio"   // 语音
+	FeatureVideo  = "message.element.video"   // 视频
+	FeatureFile   = "message.element.file"    // 文件
+	FeatureStrong = "message.element.b"       // 粗体
+	FeatureEm     = "message.element.i"       // 斜体
+	FeatureIns    = "message.element.u"       // 下划线
+	FeatureDel    = "message.element.s"       // 删除线
+	FeatureSpl    = "message.element.spl"     // 剧透
+	FeatureCode   = "message.element.code"    // 等宽文本
+	FeatureSup    = "message.element.sup"     // 上标
+	FeatureSub    = "message.element.sub"     // 下标
+	FeatureAtAll  = "message.element.at.all"  // @全体成员
+	FeatureAtHere = "message.element.at.here" // @在线成员
+)
+
+type childrenSetter interface {
+	setChildren(children []Element)
+}
+
+// Downgrade 根据平台特性将元素降级为平台支持的形式，features 通常为 login.Login.Features。
+//
+// 平台不支持时，按钮转换为链接或文本，语音、视频与文件转换为指向 src 的链接，
+// 修饰元素只保留其中的内容，@全体成员 与 @在线成员 会被移除。
+// 文本、提及、链接、图片等基础元素总是被视为支持。
+// 传入的元素不会被修改，包含子元素的元素会被复制后再替换子元素。
+func Downgrade(elements []Element, features []string) []Element {
+	set := make(map[string]struct{}, len(features))
+	for _, f := range features {
+		set[f] = struct{}{}
+	}
+	return downgrade(elements, set)
+}
+
+func downgrade(elements []Element, features map[string]struct{}) []Element {
+	supports := func(feature string) bool {
+		_, ok := features[feature]
+		return ok
+	}
+	result := make([]Element, 0, len(elements))
+	for _, e := range elements {
+		switch e := e.(type) {
+		case *Button:
+			if supports(FeatureButton) {
+				break
+			}
+			children := downgrade(e.Children(), features)
+			if len(children) == 0 && e.Text != "" {
+				children = []Element{Plain(e.Text)}
+			}
+			if e.Type == "link" && e.Href != "" {
+				result = append(result, Link(e.Href, children...))
+			} else {
+				result = append(result, children...)
+			}
+			continue
+		case *Audio, *Video, *File:
+			if supports("message.element." + e.Tag()) {
+				break
+			}
+			res := e.(ResourceElement).GetResource()
+			if res.Title != "" {
+				result = append(result, Link(res.Src, Plain(res.Title)))
+			} else {
+				result = append(result, Link(res.Src))
+			}
+			continue
+		case decorativeElement:
+			if supports("message.element." + e.(Element).Tag()) {
+				break
+			}
+			result = append(result, downgrade(e.(Element).Children(), features)...)
+			continue
+		case *At:
+			if (e.Type == "all" && !supports(FeatureAtAll)) || (e.Type == "here" && !supports(FeatureAtHere)) {
+				continue
+			}
+		}
+		if _, ok := e.(childrenSetter); ok && len(e.Children()) > 0 {
+			children := downgrade(e.Children(), features)
+			e = shallowClone(e)
+			e.(childrenSetter).setChildren(children)
+		}
+		result = append(result, e)
+	}
+	return result
+}
@@ -2,6 +2,7 @@ package element
 
 import (
 	"fmt"
+	"maps"
 	"reflect"
 	"slices"
 	"strings"
@@ -50,6 +51,7 @@ type Element interface {
 	Alias() []string
 	UnmarshalAttrs(attrs map[string]any) error
 	Get(key string) (any, bool)
+	Set(key string, value any) error
 	MarshalXHTML(strip bool) string
 	Children() []Element
 	AddChild(content ...Element)
@@ -108,6 +110,20 @@ func (e *BaseElement) Get(key string) (any, bool) {
 	return fmt.Sprint(value), true
 }
 
+// Set 设置属性，并将全部属性重新绑定到所属元素的字段上
+func (e *BaseElement) Set(key string, value any) error {
+	if e == nil {
+		return nil
+	}
+	attrs := make(map[string]any, len(e.attrs)+1)
+	maps.Copy(attrs, e.attrs)
+	attrs[key] = value
+	if e.owner != nil {
+		return e.owner.UnmarshalAttrs(attrs)
+	}
+	return e.UnmarshalAttrs(attrs)
+}
+
 func (e *BaseElement) Children() []Element {
 	if e == nil {
 		return nil
@@ -115,13 +131,37 @@ func (e *BaseElement) Children() []Element {
 	return e.children
 }
 
+// 替换全部子元素，不经过 AddChild 的过滤
+func (e *BaseElement) setChildren(children []Element) {
+	if e == nil {
+		return
+	}
+	for _, c := range children {
+		bindOwner(c)
+	}
+	e.children = children
+}
+
+// 浅拷贝元素，属性与原元素共享，子元素列表可以在拷贝上单独替换
+func shallowClone(e Element) Element {
+	rv := reflect.ValueOf(e)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return e
+	}
+	clone := reflect.New(rv.Elem().Type())
+	clone.Elem().Set(rv.Elem())
+	element := clone.Interface().(Element)
+	bindOwner(element)
+	return element
+}
+
 func (e *BaseElement) attributes() string {
 	if e == nil || len(e.attrs) == 0 {
 		return ""
 	}
 	var builder strings.Builder
-	for k, v := range e.attrs {
-		builder.WriteString(attrString(k, v))
+	for _, k := range slices.Sorted(maps.Keys(e.attrs)) {
+		builder.WriteString(attrString(k, e.attrs[k]))
 	}
 	return builder.String()
 }
@@ -131,8 +171,7 @@ func (e *BaseElement) MarshalXHTML(strip bool) string {
 		return ""
 	}
 	tag := e.ownerTag()
-	_, hasText := e.attrs["text"]
-	if tag == "text" || hasText {
+	if tag == "text" {
 		text, _ := e.Get("text")
 		if strip {
 			return fmt.Sprint(text)
@@ -275,6 +314,7 @@ func Transform(elements []*xhtml.Element) ([]Element, error) {
 				}
 				element.AddChild(children...)
 			}
+			message = append(message, element)
 		} else if slices.Contains([]string{"a", "link"}, tag) {
 			link, err := New[*A](elem.Attrs)
 			if err != nil {
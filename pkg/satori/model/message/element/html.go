@@ -0,0 +1,189 @@
+package element
+
+import (
+	"cmp"
+	"html"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// DefaultHTMLSchemes 默认允许出现在 href 与 src 中的 URL 协议，相对地址总是允许的
+var DefaultHTMLSchemes = []string{"http", "https", "mailto"}
+
+// HTMLOption HTML 渲染配置项
+type HTMLOption func(*htmlRenderer)
+
+// 设置允许的 URL 协议，不区分大小写。不在其中的链接只渲染其文本，资源元素不会被渲染
+func WithAllowedSchemes(schemes ...string) HTMLOption {
+	lower := make([]string, len(schemes))
+	for i, scheme := range schemes {
+		lower[i] = strings.ToLower(scheme)
+	}
+	return func(r *htmlRenderer) {
+		r.schemes = lower
+	}
+}
+
+// 设置 class 钩子，返回值会被追加到元素渲染后的 class 属性中，返回空字符串时不追加
+func WithClassHook(fn func(e Element) string) HTMLOption {
+	return func(r *htmlRenderer) {
+		r.class = fn
+	}
+}
+
+type htmlRenderer struct {
+	schemes []string
+	class   func(Element) string
+}
+
+// RenderHTML 将元素渲染为可以直接嵌入网页的 HTML。
+// 文本与属性均会被转义，href 与 src 只允许使用 WithAllowedSchemes 设置的协议。
+// <at> 与 <sharp> 渲染为带有 mention class 的 <span>，<spl> 渲染为 <details>，<quote> 渲染为 <blockquote>，
+// 其他不认识的元素只渲染其子元素。
+func RenderHTML(elements []Element, opts ...HTMLOption) string {
+	r := &htmlRenderer{schemes: DefaultHTMLSchemes}
+	for _, opt := range opts {
+		opt(r)
+	}
+	var builder strings.Builder
+	r.write(&builder, elements)
+	return builder.String()
+}
+
+func (r *htmlRenderer) safeURL(raw string) bool {
+	if raw == "" {
+		return false
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return false
+	}
+	return u.Scheme == "" || slices.Contains(r.schemes, u.Scheme)
+}
+
+// 写入开始标签，attrs 为属性名与属性值交替排列的列表，值为空的属性会被忽略
+func (r *htmlRenderer) open(b *strings.Builder, e Element, tag, class string, attrs ...string) {
+	if r.class != nil {
+		if extra := r.class(e); extra != "" {
+			class = strings.TrimSpace(class + " " + extra)
+		}
+	}
+	b.WriteString("<" + tag)
+	if class != "" {
+		b.WriteString(` class="` + html.EscapeString(class) + `"`)
+	}
+	for i := 0; i+1 < len(attrs); i += 2 {
+		if attrs[i+1] == "" {
+			continue
+		}
+		b.WriteString(" " + attrs[i] + `="` + html.EscapeString(attrs[i+1]) + `"`)
+	}
+	b.WriteString(">")
+}
+
+func (r *htmlRenderer) wrap(b *strings.Builder, e Element, tag, class string, attrs ...string) {
+	r.open(b, e, tag, class, attrs...)
+	r.write(b, e.Children())
+	b.WriteString("</" + tag + ">")
+}
+
+func (r *htmlRenderer) write(b *strings.Builder, elements []Element) {
+	for _, e := range elements {
+		switch e := e.(type) {
+		case *Text:
+			b.WriteString(strings.ReplaceAll(html.EscapeString(e.Text), "\n", "<br>"))
+		case *Strong:
+			r.wrap(b, e, "b", "")
+		case *Em:
+			r.wrap(b, e, "i", "")
+		case *Ins:
+			r.wrap(b, e, "u", "")
+		case *Del:
+			r.wrap(b, e, "s", "")
+		case *Code:
+			r.wrap(b, e, "code", "")
+		case *Sup:
+			r.wrap(b, e, "sup", "")
+		case *Sub:
+			r.wrap(b, e, "sub", "")
+		case *Spl:
+			r.open(b, e, "details", "spoiler")
+			b.WriteString("<summary>spoiler</summary>")
+			r.write(b, e.Children())
+			b.WriteString("</details>")
+		case *At:
+			class := "mention mention-user"
+			switch {
+			case e.Type != "":
+				class = "mention mention-" + e.Type
+			case e.Id == "" && e.Role != "":
+				class = "mention mention-role"
+			}
+			r.open(b, e, "span", class, "data-id", e.Id, "data-role", e.Role)
+			b.WriteString(html.EscapeString(defaultAtText(e)) + "</span>")
+		case *Sharp:
+			r.open(b, e, "span", "mention mention-channel", "data-id", e.Id)
+			b.WriteString(html.EscapeString(defaultSharpText(e)) + "</span>")
+		case *A:
+			if !r.safeURL(e.Href) {
+				r.write(b, e.Children())
+				continue
+			}
+			r.open(b, e, "a", "", "href", e.Href, "rel", "noopener noreferrer nofollow", "target", "_blank")
+			if len(e.Children()) == 0 {
+				b.WriteString(html.EscapeString(e.Href))
+			} else {
+				r.write(b, e.Children())
+			}
+			b.WriteString("</a>")
+		case *Img:
+			if r.safeURL(e.Src) {
+				r.open(b, e, "img", "", "src", e.Src, "alt", e.Title, "width", positive(e.Width), "height", positive(e.Height))
+			}
+		case *Audio:
+			if r.safeURL(e.Src) {
+				r.open(b, e, "audio", "", "controls", "controls", "src", e.Src)
+				b.WriteString("</audio>")
+			}
+		case *Video:
+			if r.safeURL(e.Src) {
+				poster := e.Poster
+				if !r.safeURL(poster) {
+					poster = ""
+				}
+				r.open(b, e, "video", "", "controls", "controls", "src", e.Src, "poster", poster)
+				b.WriteString("</video>")
+			}
+		case *File:
+			if r.safeURL(e.Src) {
+				r.open(b, e, "a", "file", "href", e.Src, "download", cmp.Or(e.Title, "download"), "rel", "noopener noreferrer nofollow")
+				b.WriteString(html.EscapeString(cmp.Or(e.Title, e.Src)) + "</a>")
+			}
+		case *Br:
+			b.WriteString("<br>")
+		case *P:
+			r.wrap(b, e, "p", "")
+		case *Quote:
+			if len(e.Children()) > 0 {
+				r.wrap(b, e, "blockquote", "", "data-id", e.Id)
+			}
+		case *Message:
+			r.wrap(b, e, "div", "message", "data-id", e.Id)
+		case *Button:
+			r.wrap(b, e, "button", "", "type", "button", "disabled", "disabled")
+		case *Author:
+			// 作者信息不属于消息内容
+		default:
+			r.write(b, e.Children())
+		}
+	}
+}
+
+func positive(n int) string {
+	if n <= 0 {
+		return ""
+	}
+	return strconv.Itoa(n)
+}
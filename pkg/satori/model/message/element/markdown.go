@@ -0,0 +1,633 @@
+package element
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// MarkdownOption Markdown 转换配置项
+type MarkdownOption func(*markdownDialect)
+
+// 设置剧透的定界符，例如 "||"。未设置时剧透在转换为 Markdown 时只保留其中的内容
+func WithSpoiler(delim string) MarkdownOption {
+	return func(d *markdownDialect) {
+		d.spoiler = delim
+	}
+}
+
+// 设置下划线的定界符，例如 "__"。未设置时下划线在转换为 Markdown 时只保留其中的内容。
+// 设置为 "__" 后，"__" 不再表示粗体。
+func WithUnderline(delim string) MarkdownOption {
+	return func(d *markdownDialect) {
+		d.underline = delim
+	}
+}
+
+// 使用 Discord 风格的方言，剧透为 ||x||，下划线为 __x__
+func WithDiscordDialect() MarkdownOption {
+	return func(d *markdownDialect) {
+		d.spoiler = "||"
+		d.underline = "__"
+	}
+}
+
+// 需要转义的字符，转义后的文本在解析时会被还原
+const markdownEscapes = "\\`*_~[]<>|"
+
+// 使用空的 HTML 注释分开相邻的定界符，或者分开 _ 组成的定界符与相邻的字母或数字，解析时会被忽略
+const markdownSeparator = "<!---->"
+
+type markdownDialect struct {
+	spoiler   string
+	underline string
+}
+
+func newMarkdownDialect(opts []MarkdownOption) *markdownDialect {
+	d := &markdownDialect{}
+	for _, opt := range opts {
+		opt(d)
+	}
+	return d
+}
+
+func escapeMarkdown(text string) string {
+	var builder strings.Builder
+	for _, r := range text {
+		if strings.ContainsRune(markdownEscapes, r) {
+			builder.WriteByte('\\')
+		}
+		builder.WriteRune(r)
+	}
+	return builder.String()
+}
+
+// MarshalMarkdown 将元素转换为 Markdown。
+// <p> 与 <quote> 元素转换为段落与引用块，<br> 元素转换为换行，包含换行的 <code> 元素转换为代码块，
+// <at>、<sharp> 等没有对应语法的元素转换为与 RenderText 相同的文本。
+// 文本中的空行在 Markdown 中表示分段，这样的文本经过 ParseMarkdown 后会被拆分为多个段落。
+func MarshalMarkdown(elements []Element, opts ...MarkdownOption) string {
+	d := newMarkdownDialect(opts)
+	return d.render(elements)
+}
+
+// Markdown 缓冲区，记录是否需要在下一段内容前分段
+type markdownWriter struct {
+	strings.Builder
+	pending bool
+	// 输出以 _ 组成的结束定界符结尾，紧跟字母或数字时定界符无法结束强调
+	closed bool
+}
+
+func (w *markdownWriter) block() {
+	if w.Len() > 0 {
+		if !strings.HasSuffix(w.String(), "\n") {
+			w.WriteString("\n")
+		}
+		if !strings.HasSuffix(w.String(), "\n\n") {
+			w.WriteString("\n")
+		}
+	}
+	w.pending = false
+	w.closed = false
+}
+
+func (w *markdownWriter) write(s string) {
+	if s == "" {
+		return
+	}
+	if w.pending {
+		w.block()
+	}
+	if w.closed && isWordAt(s, 0) {
+		w.WriteString(markdownSeparator)
+	}
+	w.closed = false
+	w.WriteString(s)
+}
+
+// 输出是否以未转义的字符 c 结尾
+func (w *markdownWriter) endsWith(c byte) bool {
+	s := w.String()
+	if w.pending || !strings.HasSuffix(s, string(c)) {
+		return false
+	}
+	backslashes := len(s) - 1 - len(strings.TrimRight(s[:len(s)-1], "\\"))
+	return backslashes%2 == 0
+}
+
+// 使用定界符包裹内容。定界符与前面的定界符连在一起，或者 _ 组成的开始定界符紧跟在字母或数字之后时，
+// 在两者之间插入分隔符；_ 组成的结束定界符之后的字母或数字由 write 处理
+func (w *markdownWriter) emphasis(inner, delim string) {
+	core := strings.TrimSpace(inner)
+	if delim == "" || core == "" {
+		w.write(inner)
+		return
+	}
+	wrapped := wrapMarkdown(delim, inner)
+	if strings.HasPrefix(inner, core) &&
+		(w.endsWith(delim[0]) || delim[0] == '_' && w.Len() > 0 && !w.pending && isWordBefore(w.String(), w.Len())) {
+		wrapped = markdownSeparator + wrapped
+	}
+	w.write(wrapped)
+	w.closed = delim[0] == '_' && strings.HasSuffix(wrapped, delim)
+}
+
+// 链接前未转义的 ! 会与链接组成图片，需要转义
+func (w *markdownWriter) escapeBang() {
+	if !w.endsWith('!') {
+		return
+	}
+	s := w.String()
+	w.Reset()
+	w.WriteString(s[:len(s)-1] + "\\!")
+}
+
+func (d *markdownDialect) render(elements []Element) string {
+	w := &markdownWriter{}
+	d.write(w, elements)
+	return w.String()
+}
+
+func (d *markdownDialect) write(w *markdownWriter, elements []Element) {
+	for _, e := range elements {
+		switch e := e.(type) {
+		case *Text:
+			w.write(escapeMarkdown(e.Text))
+		case *Strong:
+			w.emphasis(d.render(e.Children()), "**")
+		case *Em:
+			inner := d.render(e.Children())
+			// 内容以粗体开始或结束时使用 _，避免与粗体的定界符连在一起
+			delim := "*"
+			if strings.HasPrefix(inner, "*") || strings.HasSuffix(inner, "*") {
+				delim = "_"
+			}
+			w.emphasis(inner, delim)
+		case *Del:
+			w.emphasis(d.render(e.Children()), "~~")
+		case *Ins:
+			w.emphasis(d.render(e.Children()), d.underline)
+		case *Spl:
+			w.emphasis(d.render(e.Children()), d.spoiler)
+		case *Code:
+			code := e.MarshalXHTML(true)
+			if strings.Contains(code, "\n") {
+				w.block()
+				w.write("```\n" + code + "\n```")
+				w.pending = true
+			} else {
+				w.write(codeSpan(code))
+			}
+		case *A:
+			if len(e.Children()) == 0 && isAutolink(e.Href) {
+				w.write("<" + e.Href + ">")
+				continue
+			}
+			// 无法作为自动链接的地址作为链接的文本
+			label := escapeMarkdown(e.Href)
+			if len(e.Children()) > 0 {
+				label = d.render(e.Children())
+			}
+			w.escapeBang()
+			w.write("[" + label + "](" + markdownURL(e.Href) + ")")
+		case *Img:
+			w.write("![" + escapeMarkdown(e.Title) + "](" + markdownURL(e.Src) + ")")
+		case ResourceElement:
+			w.write("<" + e.GetResource().Src + ">")
+		case *At:
+			w.write(escapeMarkdown(defaultAtText(e)))
+		case *Sharp:
+			w.write(escapeMarkdown(defaultSharpText(e)))
+		case *Br:
+			w.write("\n")
+		case *Quote:
+			inner := d.render(e.Children())
+			if inner == "" {
+				continue
+			}
+			lines := strings.Split(inner, "\n")
+			for i, line := range lines {
+				lines[i] = strings.TrimRight("> "+line, " ")
+			}
+			w.block()
+			w.write(strings.Join(lines, "\n"))
+			w.pending = true
+		case *P, *Message:
+			w.block()
+			d.write(w, e.Children())
+			w.pending = true
+		case *Author:
+			// 作者信息不属于消息内容
+		default:
+			d.write(w, e.Children())
+		}
+	}
+}
+
+// 使用定界符包裹内容，首尾的空白会被移到定界符之外
+func wrapMarkdown(delim, inner string) string {
+	core := strings.TrimSpace(inner)
+	if delim == "" || core == "" {
+		return inner
+	}
+	start := strings.Index(inner, core)
+	return inner[:start] + delim + core + delim + inner[start+len(core):]
+}
+
+func codeSpan(code string) string {
+	longest, run := 0, 0
+	for _, r := range code {
+		if r == '`' {
+			run++
+			longest = max(longest, run)
+		} else {
+			run = 0
+		}
+	}
+	fence := strings.Repeat("`", longest+1)
+	if strings.HasPrefix(code, "`") || strings.HasSuffix(code, "`") ||
+		(strings.HasPrefix(code, " ") && strings.HasSuffix(code, " ") && strings.TrimSpace(code) != "") {
+		code = " " + code + " "
+	}
+	return fence + code + fence
+}
+
+// 链接地址中成对的括号原样保留，不成对的括号与反斜杠使用反斜杠转义
+func markdownURL(href string) string {
+	balanced, depth := true, 0
+	for _, r := range href {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			balanced = balanced && depth >= 0
+		}
+	}
+	balanced = balanced && depth == 0
+	var builder strings.Builder
+	for _, r := range href {
+		switch {
+		case r == ' ':
+			builder.WriteString("%20")
+			continue
+		case r == '\\', !balanced && (r == '(' || r == ')'):
+			builder.WriteByte('\\')
+		}
+		builder.WriteRune(r)
+	}
+	return builder.String()
+}
+
+// ParseMarkdown 将 Markdown 解析为元素，是 MarshalMarkdown 的逆操作。
+// 只有一个段落时直接返回段落中的元素，否则每个段落对应一个 <p> 元素。
+// 段落中的换行解析为 <br> 元素，不支持的语法保留为文本。
+func ParseMarkdown(source string, opts ...MarkdownOption) []Element {
+	d := newMarkdownDialect(opts)
+	return d.parseBlocks(strings.ReplaceAll(source, "\r\n", "\n"))
+}
+
+func (d *markdownDialect) parseBlocks(source string) []Element {
+	type block struct {
+		quote    bool
+		elements []Element
+	}
+	var (
+		blocks     []block
+		paragraph  []string
+		paragraphs int
+	)
+	flush := func() {
+		if len(paragraph) > 0 {
+			blocks = append(blocks, block{elements: d.parseInline(strings.Join(paragraph, "\n"))})
+			paragraphs++
+			paragraph = nil
+		}
+	}
+	lines := strings.Split(source, "\n")
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		switch {
+		case strings.TrimSpace(line) == "":
+			flush()
+		case strings.HasPrefix(line, "```"):
+			flush()
+			var code []string
+			for i++; i < len(lines) && strings.TrimSpace(lines[i]) != "```"; i++ {
+				code = append(code, lines[i])
+			}
+			blocks = append(blocks, block{elements: []Element{Monospace(Plain(strings.Join(code, "\n")))}})
+			paragraphs++
+		case strings.HasPrefix(line, ">"):
+			flush()
+			var quoted []string
+			for ; i < len(lines) && strings.HasPrefix(lines[i], ">"); i++ {
+				quoted = append(quoted, strings.TrimPrefix(strings.TrimPrefix(lines[i], ">"), " "))
+			}
+			i--
+			quote := build[*Quote](nil, d.parseBlocks(strings.Join(quoted, "\n"))...)
+			blocks = append(blocks, block{quote: true, elements: []Element{quote}})
+		default:
+			paragraph = append(paragraph, line)
+		}
+	}
+	flush()
+
+	var result []Element
+	for _, b := range blocks {
+		if !b.quote && paragraphs > 1 {
+			result = append(result, Paragraph(b.elements...))
+			continue
+		}
+		result = append(result, b.elements...)
+	}
+	return result
+}
+
+func (d *markdownDialect) parseInline(s string) []Element {
+	var (
+		result []Element
+		text   strings.Builder
+	)
+	flush := func() {
+		if text.Len() > 0 {
+			result = append(result, Plain(text.String()))
+			text.Reset()
+		}
+	}
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == '\\' && i+1 < len(s) && isASCIIPunct(s[i+1]):
+			text.WriteByte(s[i+1])
+			i += 2
+			continue
+		case c == '\n':
+			flush()
+			result = append(result, Newline())
+			i++
+			continue
+		case c == '`':
+			n := runLength(s, i)
+			if end := codeEnd(s, i+n, n); end >= 0 {
+				code := s[i+n : end]
+				if len(code) > 1 && code[0] == ' ' && code[len(code)-1] == ' ' && strings.TrimSpace(code) != "" {
+					code = code[1 : len(code)-1]
+				}
+				flush()
+				result = append(result, Monospace(Plain(code)))
+				i = end + n
+				continue
+			}
+			text.WriteString(s[i : i+n])
+			i += n
+			continue
+		case c == '!' && strings.HasPrefix(s[i:], "!["):
+			if label, href, end, ok := parseMarkdownLink(s, i+1); ok {
+				flush()
+				img := Image(href)
+				if label != "" {
+					_ = img.Set("title", unescapeMarkdown(label))
+				}
+				result = append(result, img)
+				i = end
+				continue
+			}
+		case c == '[':
+			if label, href, end, ok := parseMarkdownLink(s, i); ok {
+				flush()
+				result = append(result, Link(href, d.parseInline(label)...))
+				i = end
+				continue
+			}
+		case c == '<' && strings.HasPrefix(s[i:], "<!--"):
+			if end := strings.Index(s[i+4:], "-->"); end >= 0 {
+				i += 4 + end + 3
+				continue
+			}
+		case c == '<':
+			if end := strings.IndexByte(s[i:], '>'); end > 0 {
+				if target := s[i+1 : i+end]; isAutolink(target) {
+					flush()
+					result = append(result, Link(target))
+					i += end + 1
+					continue
+				}
+			}
+		default:
+			if delim, end, ok := d.emphasis(s, i); ok {
+				flush()
+				result = append(result, d.decorate(delim, d.parseInline(s[i+len(delim):end])))
+				i = end + len(delim)
+				continue
+			}
+		}
+		text.WriteByte(c)
+		i++
+	}
+	flush()
+	return result
+}
+
+// 可以用于强调的定界符，按长度从长到短排列
+func (d *markdownDialect) delimiters() []string {
+	delims := []string{"**", "~~", "*", "_"}
+	if d.underline != "__" {
+		delims = append(delims, "__")
+	}
+	for _, delim := range []string{d.spoiler, d.underline} {
+		if delim != "" && !slices.Contains(delims, delim) {
+			delims = append(delims, delim)
+		}
+	}
+	slices.SortStableFunc(delims, func(a, b string) int { return cmp.Compare(len(b), len(a)) })
+	return delims
+}
+
+func (d *markdownDialect) decorate(delim string, children []Element) Element {
+	switch delim {
+	case d.spoiler:
+		return Spoiler(children...)
+	case d.underline:
+		return Underline(children...)
+	case "**", "__":
+		return Bold(children...)
+	case "~~":
+		return Strike(children...)
+	default:
+		return Italic(children...)
+	}
+}
+
+// 查找从 i 开始的强调，返回定界符与结束定界符的位置
+func (d *markdownDialect) emphasis(s string, i int) (string, int, bool) {
+	for _, delim := range d.delimiters() {
+		if !strings.HasPrefix(s[i:], delim) {
+			continue
+		}
+		start := i + len(delim)
+		if start >= len(s) || isSpace(s, start) {
+			continue
+		}
+		if delim[0] == '_' && i > 0 && isWordBefore(s, i) {
+			continue
+		}
+		if end := closeDelimiter(s, start, delim); end >= 0 {
+			return delim, end, true
+		}
+	}
+	return "", 0, false
+}
+
+// 查找结束定界符，跳过转义字符与行内代码。
+// 由同一字符组成的定界符连续出现时取最后的位置，使内层的定界符先结束。
+func closeDelimiter(s string, start int, delim string) int {
+	uniform := strings.Count(delim, delim[:1]) == len(delim)
+	for j := start; j < len(s); {
+		switch {
+		case s[j] == '\\':
+			j += 2
+			continue
+		case s[j] == '`':
+			n := runLength(s, j)
+			if end := codeEnd(s, j+n, n); end >= 0 {
+				j = end + n
+			} else {
+				j += n
+			}
+			continue
+		}
+		if !strings.HasPrefix(s[j:], delim) {
+			j++
+			continue
+		}
+		run := len(delim)
+		if uniform {
+			run = runLength(s, j)
+			if run < len(delim) {
+				j += run
+				continue
+			}
+		}
+		end := j + run - len(delim)
+		after := j + run
+		if end > start && !isSpace(s, j-1) &&
+			(delim[0] != '_' || after >= len(s) || !isWordAt(s, after)) {
+			return end
+		}
+		j += run
+	}
+	return -1
+}
+
+// 解析 [label](href)，s[i] 为 '['
+func parseMarkdownLink(s string, i int) (string, string, int, bool) {
+	depth := 0
+	for j := i; j < len(s); j++ {
+		switch s[j] {
+		case '\\':
+			j++
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth > 0 {
+				continue
+			}
+			if j+1 >= len(s) || s[j+1] != '(' {
+				return "", "", 0, false
+			}
+			end := linkDestinationEnd(s, j+2)
+			if end < 0 {
+				return "", "", 0, false
+			}
+			href := unescapeMarkdown(strings.TrimSpace(s[j+2 : end]))
+			return s[i+1 : j], href, end + 1, true
+		}
+	}
+	return "", "", 0, false
+}
+
+// 查找链接地址结束的 )，地址中可以包含成对的括号与转义的括号
+func linkDestinationEnd(s string, start int) int {
+	depth := 0
+	for j := start; j < len(s); j++ {
+		switch s[j] {
+		case '\\':
+			j++
+		case '\n':
+			return -1
+		case '(':
+			depth++
+		case ')':
+			if depth == 0 {
+				return j
+			}
+			depth--
+		}
+	}
+	return -1
+}
+
+func unescapeMarkdown(text string) string {
+	var builder strings.Builder
+	for i := 0; i < len(text); i++ {
+		if text[i] == '\\' && i+1 < len(text) && isASCIIPunct(text[i+1]) {
+			i++
+		}
+		builder.WriteByte(text[i])
+	}
+	return builder.String()
+}
+
+func isAutolink(target string) bool {
+	return !strings.ContainsAny(target, " \n<>") &&
+		(strings.Contains(target, "://") || strings.HasPrefix(target, "mailto:"))
+}
+
+// s[i] 开始的相同字符的个数
+func runLength(s string, i int) int {
+	n := 1
+	for i+n < len(s) && s[i+n] == s[i] {
+		n++
+	}
+	return n
+}
+
+// 查找长度恰好为 n 的反引号序列，作为行内代码的结束位置
+func codeEnd(s string, start, n int) int {
+	for j := start; j < len(s); {
+		if s[j] != '`' {
+			j++
+			continue
+		}
+		run := runLength(s, j)
+		if run == n {
+			return j
+		}
+		j += run
+	}
+	return -1
+}
+
+func isASCIIPunct(c byte) bool {
+	return c < utf8.RuneSelf && unicode.IsPunct(rune(c)) || strings.IndexByte("$+<=>^`|~", c) >= 0
+}
+
+func isSpace(s string, i int) bool {
+	r, _ := utf8.DecodeRuneInString(s[i:])
+	return unicode.IsSpace(r)
+}
+
+func isWordAt(s string, i int) bool {
+	r, _ := utf8.DecodeRuneInString(s[i:])
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+func isWordBefore(s string, i int) bool {
+	r, _ := utf8.DecodeLastRuneInString(s[:i])
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
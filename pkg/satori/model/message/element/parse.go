@@ -0,0 +1,8 @@
+package element
+
+import "github.com/satori-protocol-go/satori-go/pkg/satori/internal/xhtml"
+
+// Parse 将消息内容解析为元素，已知的标签会被解析为对应的类型，例如 *At、*Img，未知的标签会被解析为 *Extension
+func Parse(content string) ([]Element, error) {
+	return Transform(xhtml.Parse(content, nil))
+}
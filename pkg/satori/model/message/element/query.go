@@ -0,0 +1,115 @@
+package element
+
+import (
+	"slices"
+	"strings"
+)
+
+// 选择器中的一项，combinator 为它与前一项之间的组合符
+type selectorPart struct {
+	tag        string
+	combinator string
+}
+
+// 解析选择器，选择器为空、以组合符开始或结束、出现连续的组合符时返回 nil
+func parseQuery(query string) [][]selectorPart {
+	var groups [][]selectorPart
+	for _, part := range strings.Split(query, ",") {
+		for _, c := range []string{">", "+", "~"} {
+			part = strings.ReplaceAll(part, c, " "+c+" ")
+		}
+		var group []selectorPart
+		combinator := " "
+		for _, field := range strings.Fields(part) {
+			switch field {
+			case ">", "+", "~":
+				if len(group) == 0 || combinator != " " {
+					return nil
+				}
+				combinator = field
+			default:
+				group = append(group, selectorPart{tag: field, combinator: combinator})
+				combinator = " "
+			}
+		}
+		if len(group) == 0 || combinator != " " {
+			return nil
+		}
+		groups = append(groups, group)
+	}
+	return groups
+}
+
+// 与 CSS 相同，* 只匹配元素，不匹配文本
+func matchTag(e Element, tag string) bool {
+	if tag == "*" {
+		_, ok := e.(*Text)
+		return !ok
+	}
+	return e.Tag() == tag || slices.Contains(e.Alias(), tag)
+}
+
+// Query 使用 CSS 风格的选择器按文档顺序查找元素，例如 "quote > at"、"b, i"。
+// 选择器由标签名组成，标签名可以是别名或 *，支持 , 分隔的多个选择器，
+// 以及后代 (空格)、子元素 (>)、相邻兄弟 (+) 与后续兄弟 (~) 组合符。
+// 兄弟组合符忽略元素之间的文本，"at + at" 可以匹配 <at id="1"/> <at id="2"/> 中的第二个 <at>。
+// 与 CSS 相同，* 与兄弟组合符只匹配元素，文本只能通过标签名 text 匹配。
+// 选择器格式错误时返回 nil。
+func Query(elements []Element, query string) []Element {
+	groups := parseQuery(query)
+	if len(groups) == 0 {
+		return nil
+	}
+	return queryElements(elements, groups)
+}
+
+func queryElements(elements []Element, groups [][]selectorPart) []Element {
+	var (
+		results  []Element
+		adjacent [][]selectorPart
+	)
+	base := slices.Clone(groups)
+	for _, e := range elements {
+		// 文本不是兄弟元素，既不会中断兄弟组合符，也不会被兄弟组合符匹配
+		if _, ok := e.(*Text); ok {
+			if slices.ContainsFunc(base, func(group []selectorPart) bool {
+				return len(group) == 1 && group[0].combinator != "~" && matchTag(e, group[0].tag)
+			}) {
+				results = append(results, e)
+			}
+			continue
+		}
+		local := append(slices.Clone(base), adjacent...)
+		adjacent = nil
+		var inner [][]selectorPart
+		matched := false
+		for _, group := range local {
+			if matchTag(e, group[0].tag) {
+				if len(group) == 1 {
+					matched = true
+				} else {
+					next := group[1:]
+					switch next[0].combinator {
+					case " ", ">":
+						inner = append(inner, next)
+					case "+":
+						adjacent = append(adjacent, next)
+					default:
+						base = append(base, next)
+					}
+				}
+			}
+			// 后代选择器在更深的层级中仍然有效
+			if group[0].combinator == " " {
+				inner = append(inner, group)
+			}
+		}
+		if matched {
+			results = append(results, e)
+		}
+		if len(inner) > 0 {
+			results = append(results, queryElements(e.Children(), inner)...)
+		}
+	}
+	return results
+}
@@ -15,6 +15,16 @@ type Resource struct {
 	Timeout int    `attr:"timeout,omitempty"` // 发 下载文件的最长时间 (毫秒)
 }
 
+func (r *Resource) GetResource() *Resource {
+	return r
+}
+
+// 资源元素，即 <img>、<audio>、<video> 与 <file>
+type ResourceElement interface {
+	Element
+	GetResource() *Resource
+}
+
 // <img> 元素用于表示图片。
 type Img struct {
 	BaseElement
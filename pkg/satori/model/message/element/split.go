@@ -0,0 +1,215 @@
+package element
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// SplitOption 消息拆分配置项
+type SplitOption func(*splitter)
+
+// 设置每条消息的最大文本长度，按文本与提及渲染后的字符数计算，资源元素不计入长度，不大于 0 时不限制
+func WithMaxLength(n int) SplitOption {
+	return func(s *splitter) {
+		s.maxLength = n
+	}
+}
+
+// 设置需要单独发送的资源元素，例如 "audio"、"video"，不指定标签时全部资源元素都单独发送
+func WithSeparateResources(tags ...string) SplitOption {
+	return func(s *splitter) {
+		s.separate = true
+		s.tags = tags
+	}
+}
+
+type splitter struct {
+	maxLength int
+	separate  bool
+	tags      []string
+}
+
+func (s *splitter) separated(e Element) bool {
+	if _, ok := e.(ResourceElement); !ok || !s.separate {
+		return false
+	}
+	return len(s.tags) == 0 || slices.Contains(s.tags, e.Tag())
+}
+
+// 是否包含需要单独发送的资源元素
+func (s *splitter) containsSeparated(elements []Element) bool {
+	for _, e := range elements {
+		if s.separated(e) || s.containsSeparated(e.Children()) {
+			return true
+		}
+	}
+	return false
+}
+
+// Split 将元素拆分为多条可以发送的消息。
+//
+// 需要单独发送的资源元素各自成为一条消息，其余内容按最大文本长度拆分。
+// 只有纯文本会从中间断开，优先在换行与空格处断开；提及、修饰元素、链接等其他元素总是完整地保留在同一条消息中，
+// 超出长度的 <p> 元素会拆分为多个 <p> 元素，包含需要单独发送的资源元素的 <message> 等容器元素会在资源元素处拆分。
+// <quote> 元素只保留在第一条消息的开头，第一条消息是单独发送的资源元素时与资源元素一起发送；
+// 除 <quote> 元素外没有其他内容时不返回任何消息。
+func Split(elements []Element, opts ...SplitOption) [][]Element {
+	s := &splitter{}
+	for _, opt := range opts {
+		opt(s)
+	}
+	var quotes []Element
+	c := &chunker{maxLength: s.maxLength}
+	s.split(c, elements, &quotes)
+	c.flush()
+	if len(quotes) > 0 && len(c.chunks) > 0 {
+		c.chunks[0] = append(quotes, c.chunks[0]...)
+	}
+	return c.chunks
+}
+
+func (s *splitter) split(c *chunker, elements []Element, quotes *[]Element) {
+	for _, e := range elements {
+		switch e := e.(type) {
+		case *Quote:
+			*quotes = append(*quotes, e)
+		case *Text:
+			c.addText(e)
+		case *P:
+			if !s.containsSeparated(e.Children()) && c.fits(textLength(e)) {
+				c.add(e, textLength(e))
+				continue
+			}
+			s.splitContainer(c, e, quotes)
+		default:
+			if s.separated(e) {
+				c.flush()
+				c.chunks = append(c.chunks, []Element{e})
+				continue
+			}
+			if _, ok := e.(childrenSetter); ok && s.containsSeparated(e.Children()) {
+				s.splitContainer(c, e, quotes)
+				continue
+			}
+			c.add(e, textLength(e))
+		}
+	}
+}
+
+// 拆分容器元素的子元素，每一部分放在容器元素的副本中，单独发送的资源元素不再包含在容器中
+func (s *splitter) splitContainer(c *chunker, e Element, quotes *[]Element) {
+	inner := &chunker{maxLength: s.maxLength}
+	s.split(inner, e.Children(), quotes)
+	inner.flush()
+	c.flush()
+	for i, chunk := range inner.chunks {
+		if len(chunk) == 1 && s.separated(chunk[0]) {
+			c.chunks = append(c.chunks, chunk)
+			continue
+		}
+		part := shallowClone(e)
+		part.(childrenSetter).setChildren(chunk)
+		if i == len(inner.chunks)-1 {
+			// 最后一段可以与之后的内容合并
+			c.add(part, textLength(part))
+		} else {
+			c.chunks = append(c.chunks, []Element{part})
+		}
+	}
+}
+
+// 计入长度的选项：资源元素不计入长度，链接只计算其中的文本，没有文本时计算 URL
+var lengthTextOptions = []TextOption{
+	WithResourceText(func(ResourceElement) string { return "" }),
+	WithLinkText(func(a *A, text string) string { return cmp.Or(text, a.Href) }),
+}
+
+func textLength(e Element) int {
+	return utf8.RuneCountInString(RenderText([]Element{e}, lengthTextOptions...))
+}
+
+// 拆分过程中的消息列表
+type chunker struct {
+	maxLength int
+	chunks    [][]Element
+	current   []Element
+	length    int
+}
+
+func (c *chunker) fits(n int) bool {
+	return c.maxLength <= 0 || c.length+n <= c.maxLength
+}
+
+func (c *chunker) flush() {
+	if len(c.current) > 0 {
+		c.chunks = append(c.chunks, c.current)
+		c.current = nil
+		c.length = 0
+	}
+}
+
+// 添加不可拆分的元素，当前消息放不下时开始新的消息
+func (c *chunker) add(e Element, n int) {
+	if !c.fits(n) {
+		c.flush()
+	}
+	c.current = append(c.current, e)
+	c.length += n
+}
+
+func (c *chunker) addText(t *Text) {
+	text := t.Text
+	if c.fits(utf8.RuneCountInString(text)) {
+		c.add(t, utf8.RuneCountInString(text))
+		return
+	}
+	for text != "" {
+		n := utf8.RuneCountInString(text)
+		if c.fits(n) {
+			c.add(Plain(text), n)
+			return
+		}
+		remain := c.maxLength - c.length
+		if remain <= 0 {
+			// 当前消息中已有超出长度的不可拆分元素
+			c.flush()
+			continue
+		}
+		cut := cutText(text, remain)
+		if cut == 0 {
+			if c.length > 0 {
+				c.flush()
+				continue
+			}
+			cut = runeOffset(text, remain)
+		}
+		c.add(Plain(text[:cut]), utf8.RuneCountInString(text[:cut]))
+		c.flush()
+		text = text[cut:]
+	}
+}
+
+// 在前 limit 个字符中寻找断开的位置，优先在最后一个换行之后，其次在最后一个空格之后，找不到时返回 0
+func cutText(text string, limit int) int {
+	prefix := text[:runeOffset(text, limit)]
+	if i := strings.LastIndexByte(prefix, '\n'); i >= 0 {
+		return i + 1
+	}
+	if i := strings.LastIndexByte(prefix, ' '); i >= 0 {
+		return i + 1
+	}
+	return 0
+}
+
+// 第 n 个字符的字节偏移
+func runeOffset(text string, n int) int {
+	for i := range text {
+		if n == 0 {
+			return i
+		}
+		n--
+	}
+	return len(text)
+}
@@ -0,0 +1,159 @@
+package element
+
+import "strings"
+
+// TextOption 纯文本渲染配置项
+type TextOption func(*textRenderer)
+
+// 设置 <at> 元素的渲染方式，默认为 @名称，没有名称时为 @ID
+func WithAtText(fn func(at *At) string) TextOption {
+	return func(r *textRenderer) {
+		r.at = fn
+	}
+}
+
+// 设置 <sharp> 元素的渲染方式，默认为 #名称，没有名称时为 #ID
+func WithSharpText(fn func(sharp *Sharp) string) TextOption {
+	return func(r *textRenderer) {
+		r.sharp = fn
+	}
+}
+
+// 设置资源元素的渲染方式，默认为 [image]、[audio]、[video] 与 [file]
+func WithResourceText(fn func(res ResourceElement) string) TextOption {
+	return func(r *textRenderer) {
+		r.resource = fn
+	}
+}
+
+// 设置 <a> 元素的渲染方式，text 为子元素渲染后的文本。
+// 默认为 文本 (URL)，文本为空或与 URL 相同时只保留 URL。
+func WithLinkText(fn func(a *A, text string) string) TextOption {
+	return func(r *textRenderer) {
+		r.link = fn
+	}
+}
+
+// 设置 <quote> 元素的渲染方式，text 为子元素渲染后的文本，默认忽略引用
+func WithQuoteText(fn func(quote *Quote, text string) string) TextOption {
+	return func(r *textRenderer) {
+		r.quote = fn
+	}
+}
+
+func defaultAtText(at *At) string {
+	switch {
+	case at.Type != "":
+		return "@" + at.Type
+	case at.Name != "":
+		return "@" + at.Name
+	case at.Id != "":
+		return "@" + at.Id
+	default:
+		return "@" + at.Role
+	}
+}
+
+func defaultSharpText(sharp *Sharp) string {
+	if sharp.Name != "" {
+		return "#" + sharp.Name
+	}
+	return "#" + sharp.Id
+}
+
+func defaultResourceText(res ResourceElement) string {
+	if res.Tag() == "img" {
+		return "[image]"
+	}
+	return "[" + res.Tag() + "]"
+}
+
+func defaultLinkText(a *A, text string) string {
+	if text == "" || text == a.Href {
+		return a.Href
+	}
+	return text + " (" + a.Href + ")"
+}
+
+type textRenderer struct {
+	at       func(*At) string
+	sharp    func(*Sharp) string
+	resource func(ResourceElement) string
+	link     func(*A, string) string
+	quote    func(*Quote, string) string
+}
+
+// RenderText 将元素渲染为纯文本。
+// <p> 与 <message> 元素与相邻的内容之间会确保有一个换行，<br> 元素渲染为换行，
+// 其他不认识的元素只渲染其子元素。
+func RenderText(elements []Element, opts ...TextOption) string {
+	r := &textRenderer{
+		at:       defaultAtText,
+		sharp:    defaultSharpText,
+		resource: defaultResourceText,
+		link:     defaultLinkText,
+		quote:    func(*Quote, string) string { return "" },
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r.render(elements)
+}
+
+func (r *textRenderer) render(elements []Element) string {
+	w := &textWriter{}
+	r.write(w, elements)
+	return w.String()
+}
+
+func (r *textRenderer) write(w *textWriter, elements []Element) {
+	for _, e := range elements {
+		switch e := e.(type) {
+		case *Text:
+			w.write(e.Text)
+		case *At:
+			w.write(r.at(e))
+		case *Sharp:
+			w.write(r.sharp(e))
+		case ResourceElement:
+			w.write(r.resource(e))
+		case *A:
+			w.write(r.link(e, r.render(e.Children())))
+		case *Quote:
+			w.write(r.quote(e, r.render(e.Children())))
+		case *Br:
+			w.write("\n")
+		case *P, *Message:
+			w.breakLine()
+			r.write(w, e.Children())
+			w.pending = true
+		case *Author:
+			// 作者信息不属于消息内容
+		default:
+			r.write(w, e.Children())
+		}
+	}
+}
+
+// 渲染纯文本时使用的缓冲区，记录是否需要在下一段内容前换行
+type textWriter struct {
+	strings.Builder
+	pending bool
+}
+
+func (w *textWriter) breakLine() {
+	if w.Len() > 0 && !strings.HasSuffix(w.String(), "\n") {
+		w.WriteString("\n")
+	}
+	w.pending = false
+}
+
+func (w *textWriter) write(s string) {
+	if s == "" {
+		return
+	}
+	if w.pending {
+		w.breakLine()
+	}
+	w.WriteString(s)
+}
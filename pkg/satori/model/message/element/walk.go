@@ -0,0 +1,81 @@
+package element
+
+import "errors"
+
+// SkipChildren 由 Walk 的 pre 回调返回时跳过当前元素的子元素，它不会被当作错误返回
+var SkipChildren = errors.New("skip children")
+
+// Walk 深度优先遍历元素，进入元素时调用 pre，离开元素时调用 post，二者均可为 nil。
+// pre 返回 SkipChildren 时跳过当前元素的子元素，post 仍会被调用；
+// 回调返回其他错误时停止遍历并返回该错误。
+func Walk(elements []Element, pre, post func(e Element) error) error {
+	for _, e := range elements {
+		if err := walk(e, pre, post); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func walk(e Element, pre, post func(e Element) error) error {
+	skip := false
+	if pre != nil {
+		if err := pre(e); errors.Is(err, SkipChildren) {
+			skip = true
+		} else if err != nil {
+			return err
+		}
+	}
+	if !skip {
+		if err := Walk(e.Children(), pre, post); err != nil {
+			return err
+		}
+	}
+	if post != nil {
+		if err := post(e); err != nil && !errors.Is(err, SkipChildren) {
+			return err
+		}
+	}
+	return nil
+}
+
+// Map 自底向上改写元素，fn 接收子元素已经改写完成的元素，返回值替换原元素，返回 nil 时删除原元素。
+// 返回改写后的元素列表，传入的元素不会被修改，包含子元素的元素会被复制后再替换子元素。
+func Map(elements []Element, fn func(e Element) Element) []Element {
+	return rewrite(elements, func(_, e Element) []Element {
+		if replaced := fn(e); replaced != nil {
+			return []Element{replaced}
+		}
+		return nil
+	})
+}
+
+// Replace 将匹配选择器的元素替换为 fn 返回的零个或多个元素，选择器的语法参见 Query。
+// 返回替换后的元素列表，传入的元素不会被修改。
+func Replace(elements []Element, query string, fn func(e Element) []Element) []Element {
+	matched := make(map[Element]struct{})
+	for _, e := range Query(elements, query) {
+		matched[e] = struct{}{}
+	}
+	return rewrite(elements, func(original, e Element) []Element {
+		if _, ok := matched[original]; ok {
+			return fn(e)
+		}
+		return []Element{e}
+	})
+}
+
+// 自底向上改写元素，fn 接收原元素与子元素已经改写完成的副本
+func rewrite(elements []Element, fn func(original, e Element) []Element) []Element {
+	result := make([]Element, 0, len(elements))
+	for _, original := range elements {
+		e := original
+		if _, ok := e.(childrenSetter); ok && len(e.Children()) > 0 {
+			children := rewrite(e.Children(), fn)
+			e = shallowClone(e)
+			e.(childrenSetter).setChildren(children)
+		}
+		result = append(result, fn(original, e)...)
+	}
+	return result
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/satori-protocol-go/satori-go/pkg/satori/model/channel"
 	"github.com/satori-protocol-go/satori-go/pkg/satori/model/guild"
 	"github.com/satori-protocol-go/satori-go/pkg/satori/model/guildmember"
+	"github.com/satori-protocol-go/satori-go/pkg/satori/model/message/element"
 	"github.com/satori-protocol-go/satori-go/pkg/satori/model/user"
 )
 
@@ -18,3 +19,8 @@ type Message struct {
 	CreateAt int64                    `json:"create_at,omitempty"` // 消息发送的时间戳
 	UpdateAt int64                    `json:"update_at,omitempty"` // 消息修改的时间戳
 }
+
+// 将消息内容解析为元素
+func (m *Message) Elements() ([]element.Element, error) {
+	return element.Parse(m.Content)
+}
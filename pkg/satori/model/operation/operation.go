@@ -1,6 +1,8 @@
 package operation
 
 import (
+	"encoding/json"
+
 	"github.com/satori-protocol-go/satori-go/pkg/satori/model/event"
 	"github.com/satori-protocol-go/satori-go/pkg/satori/model/login"
 )
@@ -23,6 +25,40 @@ type Operation struct {
 	Body any    `json:"body,omitempty"` // 信令数据
 }
 
+// UnmarshalJSON 根据信令类型将 Body 解码为 *EventBody、*IdentifyBody、*ReadyBody 或 *MetaBody。
+// 没有数据的信令 Body 为 nil。
+func (o *Operation) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Op   Opcode          `json:"op"`
+		Body json.RawMessage `json:"body,omitempty"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	o.Op = raw.Op
+	o.Body = nil
+
+	var body any
+	switch raw.Op {
+	case OpcodeEvent:
+		body = &EventBody{}
+	case OpcodeIdentify:
+		body = &IdentifyBody{}
+	case OpcodeReady:
+		body = &ReadyBody{}
+	case OpcodeMeta:
+		body = &MetaBody{}
+	}
+	if body == nil || len(raw.Body) == 0 || string(raw.Body) == "null" {
+		return nil
+	}
+	if err := json.Unmarshal(raw.Body, body); err != nil {
+		return err
+	}
+	o.Body = body
+	return nil
+}
+
 // EVENT 信令的 Body 数据
 type EventBody event.Event
 
@@ -1 +1,7 @@
 package reaction
+
+// 表情
+type Emoji struct {
+	Id   string `json:"id"`             // 表情 ID
+	Name string `json:"name,omitempty"` // 表情名称
+}
@@ -0,0 +1,73 @@
+package router
+
+import (
+	"context"
+
+	"github.com/satori-protocol-go/satori-go/pkg/satori/client"
+	"github.com/satori-protocol-go/satori-go/pkg/satori/model/event"
+)
+
+// Next 继续执行后续的中间件与处理器
+type Next func(ctx context.Context)
+
+// Middleware 事件中间件。
+// 中间件可以在调用 next 前后执行逻辑，不调用 next 即可中断对该事件的后续处理。
+type Middleware func(ctx context.Context, e *event.Event, next Next)
+
+// Use 为路由器添加中间件，中间件按添加顺序包裹该路由器的全部处理器
+func (r *Router) Use(mw ...Middleware) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.middlewares = append(r.middlewares, mw...)
+}
+
+// Group 创建一个挂载在当前路由器上的子路由器，子路由器额外使用 mw 作为中间件
+func (r *Router) Group(mw ...Middleware) *Router {
+	group := New()
+	group.Use(mw...)
+	r.Handle("*", group)
+	return group
+}
+
+// Wrap 为单个处理器附加中间件
+func Wrap(handler client.Handler, mw ...Middleware) client.Handler {
+	return client.HandlerFunc(func(ctx context.Context, e *event.Event) {
+		chain(mw, e, func(ctx context.Context) {
+			handler.HandleEvent(ctx, e)
+		})(ctx)
+	})
+}
+
+// Only 仅对匹配 pattern 的事件执行 mw，其余事件直接交给后续处理，pattern 规则参见 Router.Handle
+func Only(pattern string, mw Middleware) Middleware {
+	return func(ctx context.Context, e *event.Event, next Next) {
+		if Match(pattern, e.Type) {
+			mw(ctx, e, next)
+			return
+		}
+		next(ctx)
+	}
+}
+
+// Recover 捕获后续处理中发生的 panic 并交给 fn 处理
+func Recover(fn func(ctx context.Context, e *event.Event, recovered any)) Middleware {
+	return func(ctx context.Context, e *event.Event, next Next) {
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				fn(ctx, e, recovered)
+			}
+		}()
+		next(ctx)
+	}
+}
+
+func chain(mw []Middleware, e *event.Event, final Next) Next {
+	next := final
+	for i := len(mw) - 1; i >= 0; i-- {
+		current, following := mw[i], next
+		next = func(ctx context.Context) {
+			current(ctx, e, following)
+		}
+	}
+	return next
+}
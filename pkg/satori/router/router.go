@@ -0,0 +1,67 @@
+package router
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/satori-protocol-go/satori-go/pkg/satori/client"
+	"github.com/satori-protocol-go/satori-go/pkg/satori/model/event"
+)
+
+type route struct {
+	pattern string
+	handler client.Handler
+}
+
+// Router 按事件类型分发事件，本身也实现了 client.Handler，可以直接交给 WebSocket 或 Webhook 使用。
+type Router struct {
+	mu          sync.RWMutex
+	routes      []route
+	middlewares []Middleware
+}
+
+func New() *Router {
+	return &Router{}
+}
+
+// Handle 注册事件处理器。
+// pattern 可以是事件类型、"*" (匹配全部事件) 或以 "*" 结尾的前缀，例如 "guild-member-*" 与 "interaction/*"。
+// 同一事件匹配到的多个处理器按注册顺序依次调用。
+func (r *Router) Handle(pattern string, handler client.Handler) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.routes = append(r.routes, route{pattern: pattern, handler: handler})
+}
+
+// HandleFunc 注册事件处理函数，参见 Handle
+func (r *Router) HandleFunc(pattern string, fn func(ctx context.Context, e *event.Event)) {
+	r.Handle(pattern, client.HandlerFunc(fn))
+}
+
+func (r *Router) HandleEvent(ctx context.Context, e *event.Event) {
+	if e == nil {
+		return
+	}
+	r.mu.RLock()
+	routes, middlewares := r.routes, r.middlewares
+	r.mu.RUnlock()
+	chain(middlewares, e, func(ctx context.Context) {
+		for _, rt := range routes {
+			if Match(rt.pattern, e.Type) {
+				rt.handler.HandleEvent(ctx, e)
+			}
+		}
+	})(ctx)
+}
+
+// Match 判断事件类型是否匹配 pattern，规则参见 Router.Handle
+func Match(pattern string, typ event.EventType) bool {
+	if pattern == "*" {
+		return true
+	}
+	if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+		return strings.HasPrefix(string(typ), prefix)
+	}
+	return pattern == string(typ)
+}
@@ -0,0 +1,206 @@
+package router
+
+import (
+	"context"
+
+	"github.com/satori-protocol-go/satori-go/pkg/satori/model/event"
+	"github.com/satori-protocol-go/satori-go/pkg/satori/model/guild"
+	"github.com/satori-protocol-go/satori-go/pkg/satori/model/guildmember"
+	"github.com/satori-protocol-go/satori-go/pkg/satori/model/guildrole"
+	"github.com/satori-protocol-go/satori-go/pkg/satori/model/interaction"
+	"github.com/satori-protocol-go/satori-go/pkg/satori/model/login"
+	"github.com/satori-protocol-go/satori-go/pkg/satori/model/message"
+	"github.com/satori-protocol-go/satori-go/pkg/satori/model/reaction"
+	"github.com/satori-protocol-go/satori-go/pkg/satori/model/user"
+)
+
+// 群组事件处理函数
+type GuildHandlerFunc func(context.Context, *event.Event, *guild.Guild)
+
+// 注册 guild-added 事件的处理函数
+func (r *Router) OnGuildAdded(fn GuildHandlerFunc) {
+	r.HandleFunc(string(event.EventTypeGuildAdded), func(ctx context.Context, e *event.Event) {
+		fn(ctx, e, e.Guild)
+	})
+}
+
+// 注册 guild-updated 事件的处理函数
+func (r *Router) OnGuildUpdated(fn GuildHandlerFunc) {
+	r.HandleFunc(string(event.EventTypeGuildUpdated), func(ctx context.Context, e *event.Event) {
+		fn(ctx, e, e.Guild)
+	})
+}
+
+// 注册 guild-removed 事件的处理函数
+func (r *Router) OnGuildRemoved(fn GuildHandlerFunc) {
+	r.HandleFunc(string(event.EventTypeGuildRemoved), func(ctx context.Context, e *event.Event) {
+		fn(ctx, e, e.Guild)
+	})
+}
+
+// 注册 guild-request 事件的处理函数
+func (r *Router) OnGuildRequest(fn GuildHandlerFunc) {
+	r.HandleFunc(string(event.EventTypeGuildRequest), func(ctx context.Context, e *event.Event) {
+		fn(ctx, e, e.Guild)
+	})
+}
+
+// 群组成员事件处理函数
+type GuildMemberHandlerFunc func(context.Context, *event.Event, *guild.Guild, *guildmember.GuildMember, *user.User)
+
+// 注册 guild-member-added 事件的处理函数
+func (r *Router) OnGuildMemberAdded(fn GuildMemberHandlerFunc) {
+	r.HandleFunc(string(event.EventTypeGuildMemberAdded), func(ctx context.Context, e *event.Event) {
+		fn(ctx, e, e.Guild, e.Member, e.User)
+	})
+}
+
+// 注册 guild-member-updated 事件的处理函数
+func (r *Router) OnGuildMemberUpdated(fn GuildMemberHandlerFunc) {
+	r.HandleFunc(string(event.EventTypeGuildMemberUpdated), func(ctx context.Context, e *event.Event) {
+		fn(ctx, e, e.Guild, e.Member, e.User)
+	})
+}
+
+// 注册 guild-member-removed 事件的处理函数
+func (r *Router) OnGuildMemberRemoved(fn GuildMemberHandlerFunc) {
+	r.HandleFunc(string(event.EventTypeGuildMemberRemoved), func(ctx context.Context, e *event.Event) {
+		fn(ctx, e, e.Guild, e.Member, e.User)
+	})
+}
+
+// 注册 guild-member-request 事件的处理函数
+func (r *Router) OnGuildMemberRequest(fn GuildMemberHandlerFunc) {
+	r.HandleFunc(string(event.EventTypeGuildMemberRequest), func(ctx context.Context, e *event.Event) {
+		fn(ctx, e, e.Guild, e.Member, e.User)
+	})
+}
+
+// 群组角色事件处理函数
+type GuildRoleHandlerFunc func(context.Context, *event.Event, *guild.Guild, *guildrole.GuildRole)
+
+// 注册 guild-role-created 事件的处理函数
+func (r *Router) OnGuildRoleCreated(fn GuildRoleHandlerFunc) {
+	r.HandleFunc(string(event.EventTypeGuildRoleCreated), func(ctx context.Context, e *event.Event) {
+		fn(ctx, e, e.Guild, e.Role)
+	})
+}
+
+// 注册 guild-role-updated 事件的处理函数
+func (r *Router) OnGuildRoleUpdated(fn GuildRoleHandlerFunc) {
+	r.HandleFunc(string(event.EventTypeGuildRoleUpdated), func(ctx context.Context, e *event.Event) {
+		fn(ctx, e, e.Guild, e.Role)
+	})
+}
+
+// 注册 guild-role-deleted 事件的处理函数
+func (r *Router) OnGuildRoleDeleted(fn GuildRoleHandlerFunc) {
+	r.HandleFunc(string(event.EventTypeGuildRoleDeleted), func(ctx context.Context, e *event.Event) {
+		fn(ctx, e, e.Guild, e.Role)
+	})
+}
+
+// 按钮交互事件处理函数
+type ButtonHandlerFunc func(context.Context, *event.Event, *interaction.Button)
+
+// 注册 interaction/button 事件的处理函数
+func (r *Router) OnInteractionButton(fn ButtonHandlerFunc) {
+	r.HandleFunc(string(event.EventTypeInteractionButton), func(ctx context.Context, e *event.Event) {
+		fn(ctx, e, e.Button)
+	})
+}
+
+// 指令交互事件处理函数
+type CommandHandlerFunc func(context.Context, *event.Event, *interaction.Argv)
+
+// 注册 interaction/command 事件的处理函数
+func (r *Router) OnInteractionCommand(fn CommandHandlerFunc) {
+	r.HandleFunc(string(event.EventTypeInteractionCommand), func(ctx context.Context, e *event.Event) {
+		fn(ctx, e, e.Argv)
+	})
+}
+
+// 登录事件处理函数
+type LoginHandlerFunc func(context.Context, *event.Event, *login.Login)
+
+// 注册 login-added 事件的处理函数
+func (r *Router) OnLoginAdded(fn LoginHandlerFunc) {
+	r.HandleFunc(string(event.EventTypeLoginAdded), func(ctx context.Context, e *event.Event) {
+		fn(ctx, e, e.Login)
+	})
+}
+
+// 注册 login-removed 事件的处理函数
+func (r *Router) OnLoginRemoved(fn LoginHandlerFunc) {
+	r.HandleFunc(string(event.EventTypeLoginRemoved), func(ctx context.Context, e *event.Event) {
+		fn(ctx, e, e.Login)
+	})
+}
+
+// 注册 login-updated 事件的处理函数
+func (r *Router) OnLoginUpdated(fn LoginHandlerFunc) {
+	r.HandleFunc(string(event.EventTypeLoginUpdated), func(ctx context.Context, e *event.Event) {
+		fn(ctx, e, e.Login)
+	})
+}
+
+// 消息事件处理函数
+type MessageHandlerFunc func(context.Context, *event.Event, *message.Message)
+
+// 注册 message-created 事件的处理函数
+func (r *Router) OnMessageCreated(fn MessageHandlerFunc) {
+	r.HandleFunc(string(event.EventTypeMessageCreated), func(ctx context.Context, e *event.Event) {
+		fn(ctx, e, e.Message)
+	})
+}
+
+// 注册 message-updated 事件的处理函数
+func (r *Router) OnMessageUpdated(fn MessageHandlerFunc) {
+	r.HandleFunc(string(event.EventTypeMessageUpdated), func(ctx context.Context, e *event.Event) {
+		fn(ctx, e, e.Message)
+	})
+}
+
+// 注册 message-deleted 事件的处理函数
+func (r *Router) OnMessageDeleted(fn MessageHandlerFunc) {
+	r.HandleFunc(string(event.EventTypeMessageDeleted), func(ctx context.Context, e *event.Event) {
+		fn(ctx, e, e.Message)
+	})
+}
+
+// 表态事件处理函数
+type ReactionHandlerFunc func(context.Context, *event.Event, *message.Message, *reaction.Emoji, *user.User)
+
+// 注册 reaction-added 事件的处理函数
+func (r *Router) OnReactionAdded(fn ReactionHandlerFunc) {
+	r.HandleFunc(string(event.EventTypeReactionAdded), func(ctx context.Context, e *event.Event) {
+		fn(ctx, e, e.Message, e.Emoji, e.User)
+	})
+}
+
+// 注册 reaction-removed 事件的处理函数
+func (r *Router) OnReactionRemoved(fn ReactionHandlerFunc) {
+	r.HandleFunc(string(event.EventTypeReactionRemoved), func(ctx context.Context, e *event.Event) {
+		fn(ctx, e, e.Message, e.Emoji, e.User)
+	})
+}
+
+// 好友申请事件处理函数
+type FriendRequestHandlerFunc func(context.Context, *event.Event, *user.User, *message.Message)
+
+// 注册 friend-request 事件的处理函数
+func (r *Router) OnFriendRequest(fn FriendRequestHandlerFunc) {
+	r.HandleFunc(string(event.EventTypeFriendRequest), func(ctx context.Context, e *event.Event) {
+		fn(ctx, e, e.User, e.Message)
+	})
+}
+
+// 内部事件处理函数
+type InternalHandlerFunc func(context.Context, *event.Event, string, any)
+
+// 注册 internal 事件的处理函数
+func (r *Router) OnInternal(fn InternalHandlerFunc) {
+	r.HandleFunc(string(event.EventTypeInternal), func(ctx context.Context, e *event.Event) {
+		fn(ctx, e, e.Type_, e.Data_)
+	})
+}
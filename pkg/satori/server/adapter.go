@@ -0,0 +1,217 @@
+package server
+
+import (
+	"context"
+	"errors"
+
+	"github.com/satori-protocol-go/satori-go/pkg/satori/model/channel"
+	"github.com/satori-protocol-go/satori-go/pkg/satori/model/define"
+	"github.com/satori-protocol-go/satori-go/pkg/satori/model/guild"
+	"github.com/satori-protocol-go/satori-go/pkg/satori/model/guildmember"
+	"github.com/satori-protocol-go/satori-go/pkg/satori/model/guildrole"
+	"github.com/satori-protocol-go/satori-go/pkg/satori/model/login"
+	"github.com/satori-protocol-go/satori-go/pkg/satori/model/message"
+	"github.com/satori-protocol-go/satori-go/pkg/satori/model/user"
+)
+
+// 适配器未实现对应的 API，服务端会以 405 响应
+var ErrNotImplemented = errors.New("satori: method not implemented")
+
+// Adapter 平台适配器，每个方法对应一个 Satori API。
+// 当前请求的平台名称与平台账号可以通过 LoginFromContext 获取。
+// 除 Logins 外，方法签名与 client.Client 保持一致。
+type Adapter interface {
+	// 当前全部的登录信息，用于构造 READY 信令
+	Logins(ctx context.Context) ([]*login.Login, error)
+
+	MessageCreate(ctx context.Context, channelId, content string) ([]*message.Message, error)
+	MessageGet(ctx context.Context, channelId, messageId string) (*message.Message, error)
+	MessageDelete(ctx context.Context, channelId, messageId string) error
+	MessageUpdate(ctx context.Context, channelId, messageId, content string) error
+	MessageList(ctx context.Context, channelId, next string, direction define.Direction, limit int, order define.Order) (*define.BidiPaginated[message.Message], error)
+
+	ChannelGet(ctx context.Context, channelId string) (*channel.Channel, error)
+	ChannelList(ctx context.Context, guildId, next string) (*define.Paginated[channel.Channel], error)
+	ChannelCreate(ctx context.Context, guildId string, data *channel.Channel) (*channel.Channel, error)
+	ChannelUpdate(ctx context.Context, channelId string, data *channel.Channel) error
+	ChannelDelete(ctx context.Context, channelId string) error
+	ChannelMute(ctx context.Context, channelId string, duration int64) error
+	UserChannelCreate(ctx context.Context, userId, guildId string) (*channel.Channel, error)
+
+	GuildGet(ctx context.Context, guildId string) (*guild.Guild, error)
+	GuildList(ctx context.Context, next string) (*define.Paginated[guild.Guild], error)
+	GuildApprove(ctx context.Context, messageId string, approve bool, comment string) error
+
+	GuildMemberGet(ctx context.Context, guildId, userId string) (*guildmember.GuildMember, error)
+	GuildMemberList(ctx context.Context, guildId, next string) (*define.Paginated[guildmember.GuildMember], error)
+	GuildMemberKick(ctx context.Context, guildId, userId string, permanent bool) error
+	GuildMemberMute(ctx context.Context, guildId, userId string, duration int64) error
+	GuildMemberApprove(ctx context.Context, messageId string, approve bool, comment string) error
+	GuildMemberRoleSet(ctx context.Context, guildId, userId, roleId string) error
+	GuildMemberRoleUnset(ctx context.Context, guildId, userId, roleId string) error
+
+	GuildRoleList(ctx context.Context, guildId, next string) (*define.Paginated[guildrole.GuildRole], error)
+	GuildRoleCreate(ctx context.Context, guildId string, role *guildrole.GuildRole) (*guildrole.GuildRole, error)
+	GuildRoleUpdate(ctx context.Context, guildId, roleId string, role *guildrole.GuildRole) error
+	GuildRoleDelete(ctx context.Context, guildId, roleId string) error
+
+	ReactionCreate(ctx context.Context, channelId, messageId, emoji string) error
+	ReactionDelete(ctx context.Context, channelId, messageId, emoji, userId string) error
+	ReactionClear(ctx context.Context, channelId, messageId, emoji string) error
+	ReactionList(ctx context.Context, channelId, messageId, emoji, next string) (*define.Paginated[user.User], error)
+
+	LoginGet(ctx context.Context) (*login.Login, error)
+	UserGet(ctx context.Context, userId string) (*user.User, error)
+	FriendList(ctx context.Context, next string) (*define.Paginated[user.User], error)
+	FriendApprove(ctx context.Context, messageId string, approve bool, comment string) error
+
+	UploadCreate(ctx context.Context, files map[string]*define.UploadFile) (map[string]string, error)
+}
+
+// UnimplementedAdapter 所有 API 都返回 ErrNotImplemented。
+// 适配器可以嵌入它，只实现平台支持的方法。
+type UnimplementedAdapter struct{}
+
+func (UnimplementedAdapter) Logins(ctx context.Context) ([]*login.Login, error) {
+	return nil, nil
+}
+
+func (UnimplementedAdapter) MessageCreate(ctx context.Context, channelId, content string) ([]*message.Message, error) {
+	return nil, ErrNotImplemented
+}
+
+func (UnimplementedAdapter) MessageGet(ctx context.Context, channelId, messageId string) (*message.Message, error) {
+	return nil, ErrNotImplemented
+}
+
+func (UnimplementedAdapter) MessageDelete(ctx context.Context, channelId, messageId string) error {
+	return ErrNotImplemented
+}
+
+func (UnimplementedAdapter) MessageUpdate(ctx context.Context, channelId, messageId, content string) error {
+	return ErrNotImplemented
+}
+
+func (UnimplementedAdapter) MessageList(ctx context.Context, channelId, next string, direction define.Direction, limit int, order define.Order) (*define.BidiPaginated[message.Message], error) {
+	return nil, ErrNotImplemented
+}
+
+func (UnimplementedAdapter) ChannelGet(ctx context.Context, channelId string) (*channel.Channel, error) {
+	return nil, ErrNotImplemented
+}
+
+func (UnimplementedAdapter) ChannelList(ctx context.Context, guildId, next string) (*define.Paginated[channel.Channel], error) {
+	return nil, ErrNotImplemented
+}
+
+func (UnimplementedAdapter) ChannelCreate(ctx context.Context, guildId string, data *channel.Channel) (*channel.Channel, error) {
+	return nil, ErrNotImplemented
+}
+
+func (UnimplementedAdapter) ChannelUpdate(ctx context.Context, channelId string, data *channel.Channel) error {
+	return ErrNotImplemented
+}
+
+func (UnimplementedAdapter) ChannelDelete(ctx context.Context, channelId string) error {
+	return ErrNotImplemented
+}
+
+func (UnimplementedAdapter) ChannelMute(ctx context.Context, channelId string, duration int64) error {
+	return ErrNotImplemented
+}
+
+func (UnimplementedAdapter) UserChannelCreate(ctx context.Context, userId, guildId string) (*channel.Channel, error) {
+	return nil, ErrNotImplemented
+}
+
+func (UnimplementedAdapter) GuildGet(ctx context.Context, guildId string) (*guild.Guild, error) {
+	return nil, ErrNotImplemented
+}
+
+func (UnimplementedAdapter) GuildList(ctx context.Context, next string) (*define.Paginated[guild.Guild], error) {
+	return nil, ErrNotImplemented
+}
+
+func (UnimplementedAdapter) GuildApprove(ctx context.Context, messageId string, approve bool, comment string) error {
+	return ErrNotImplemented
+}
+
+func (UnimplementedAdapter) GuildMemberGet(ctx context.Context, guildId, userId string) (*guildmember.GuildMember, error) {
+	return nil, ErrNotImplemented
+}
+
+func (UnimplementedAdapter) GuildMemberList(ctx context.Context, guildId, next string) (*define.Paginated[guildmember.GuildMember], error) {
+	return nil, ErrNotImplemented
+}
+
+func (UnimplementedAdapter) GuildMemberKick(ctx context.Context, guildId, userId string, permanent bool) error {
+	return ErrNotImplemented
+}
+
+func (UnimplementedAdapter) GuildMemberMute(ctx context.Context, guildId, userId string, duration int64) error {
+	return ErrNotImplemented
+}
+
+func (UnimplementedAdapter) GuildMemberApprove(ctx context.Context, messageId string, approve bool, comment string) error {
+	return ErrNotImplemented
+}
+
+func (UnimplementedAdapter) GuildMemberRoleSet(ctx context.Context, guildId, userId, roleId string) error {
+	return ErrNotImplemented
+}
+
+func (UnimplementedAdapter) GuildMemberRoleUnset(ctx context.Context, guildId, userId, roleId string) error {
+	return ErrNotImplemented
+}
+
+func (UnimplementedAdapter) GuildRoleList(ctx context.Context, guildId, next string) (*define.Paginated[guildrole.GuildRole], error) {
+	return nil, ErrNotImplemented
+}
+
+func (UnimplementedAdapter) GuildRoleCreate(ctx context.Context, guildId string, role *guildrole.GuildRole) (*guildrole.GuildRole, error) {
+	return nil, ErrNotImplemented
+}
+
+func (UnimplementedAdapter) GuildRoleUpdate(ctx context.Context, guildId, roleId string, role *guildrole.GuildRole) error {
+	return ErrNotImplemented
+}
+
+func (UnimplementedAdapter) GuildRoleDelete(ctx context.Context, guildId, roleId string) error {
+	return ErrNotImplemented
+}
+
+func (UnimplementedAdapter) ReactionCreate(ctx context.Context, channelId, messageId, emoji string) error {
+	return ErrNotImplemented
+}
+
+func (UnimplementedAdapter) ReactionDelete(ctx context.Context, channelId, messageId, emoji, userId string) error {
+	return ErrNotImplemented
+}
+
+func (UnimplementedAdapter) ReactionClear(ctx context.Context, channelId, messageId, emoji string) error {
+	return ErrNotImplemented
+}
+
+func (UnimplementedAdapter) ReactionList(ctx context.Context, channelId, messageId, emoji, next string) (*define.Paginated[user.User], error) {
+	return nil, ErrNotImplemented
+}
+
+func (UnimplementedAdapter) LoginGet(ctx context.Context) (*login.Login, error) {
+	return nil, ErrNotImplemented
+}
+
+func (UnimplementedAdapter) UserGet(ctx context.Context, userId string) (*user.User, error) {
+	return nil, ErrNotImplemented
+}
+
+func (UnimplementedAdapter) FriendList(ctx context.Context, next string) (*define.Paginated[user.User], error) {
+	return nil, ErrNotImplemented
+}
+
+func (UnimplementedAdapter) FriendApprove(ctx context.Context, messageId string, approve bool, comment string) error {
+	return ErrNotImplemented
+}
+
+func (UnimplementedAdapter) UploadCreate(ctx context.Context, files map[string]*define.UploadFile) (map[string]string, error) {
+	return nil, ErrNotImplemented
+}
@@ -0,0 +1,239 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/satori-protocol-go/satori-go/pkg/satori/model/channel"
+	"github.com/satori-protocol-go/satori-go/pkg/satori/model/define"
+	"github.com/satori-protocol-go/satori-go/pkg/satori/model/guildrole"
+)
+
+// ErrStatus 携带 HTTP 状态码的错误，适配器可以返回它来指定响应的状态码
+type ErrStatus struct {
+	StatusCode int    // HTTP 状态码
+	Message    string // 响应内容
+}
+
+func (e *ErrStatus) Error() string {
+	return fmt.Sprintf("satori: status %d: %s", e.StatusCode, e.Message)
+}
+
+// API 请求参数，包含所有 API 可能用到的字段
+type request struct {
+	ChannelId string               `json:"channel_id"`
+	GuildId   string               `json:"guild_id"`
+	MessageId string               `json:"message_id"`
+	UserId    string               `json:"user_id"`
+	RoleId    string               `json:"role_id"`
+	Content   string               `json:"content"`
+	Emoji     string               `json:"emoji"`
+	Next      string               `json:"next"`
+	Direction define.Direction     `json:"direction"`
+	Limit     int                  `json:"limit"`
+	Order     define.Order         `json:"order"`
+	Approve   bool                 `json:"approve"`
+	Comment   string               `json:"comment"`
+	Permanent bool                 `json:"permanent"`
+	Duration  int64                `json:"duration"`
+	Data      *channel.Channel     `json:"data"`
+	Role      *guildrole.GuildRole `json:"role"`
+}
+
+type method func(ctx context.Context, a Adapter, r *request) (any, error)
+
+func void(err error) (any, error) {
+	return nil, err
+}
+
+var methods = map[string]method{
+	"message.create": func(ctx context.Context, a Adapter, r *request) (any, error) {
+		return a.MessageCreate(ctx, r.ChannelId, r.Content)
+	},
+	"message.get": func(ctx context.Context, a Adapter, r *request) (any, error) {
+		return a.MessageGet(ctx, r.ChannelId, r.MessageId)
+	},
+	"message.delete": func(ctx context.Context, a Adapter, r *request) (any, error) {
+		return void(a.MessageDelete(ctx, r.ChannelId, r.MessageId))
+	},
+	"message.update": func(ctx context.Context, a Adapter, r *request) (any, error) {
+		return void(a.MessageUpdate(ctx, r.ChannelId, r.MessageId, r.Content))
+	},
+	"message.list": func(ctx context.Context, a Adapter, r *request) (any, error) {
+		return a.MessageList(ctx, r.ChannelId, r.Next, r.Direction, r.Limit, r.Order)
+	},
+
+	"channel.get": func(ctx context.Context, a Adapter, r *request) (any, error) {
+		return a.ChannelGet(ctx, r.ChannelId)
+	},
+	"channel.list": func(ctx context.Context, a Adapter, r *request) (any, error) {
+		return a.ChannelList(ctx, r.GuildId, r.Next)
+	},
+	"channel.create": func(ctx context.Context, a Adapter, r *request) (any, error) {
+		return a.ChannelCreate(ctx, r.GuildId, r.Data)
+	},
+	"channel.update": func(ctx context.Context, a Adapter, r *request) (any, error) {
+		return void(a.ChannelUpdate(ctx, r.ChannelId, r.Data))
+	},
+	"channel.delete": func(ctx context.Context, a Adapter, r *request) (any, error) {
+		return void(a.ChannelDelete(ctx, r.ChannelId))
+	},
+	"channel.mute": func(ctx context.Context, a Adapter, r *request) (any, error) {
+		return void(a.ChannelMute(ctx, r.ChannelId, r.Duration))
+	},
+	"user.channel.create": func(ctx context.Context, a Adapter, r *request) (any, error) {
+		return a.UserChannelCreate(ctx, r.UserId, r.GuildId)
+	},
+
+	"guild.get": func(ctx context.Context, a Adapter, r *request) (any, error) {
+		return a.GuildGet(ctx, r.GuildId)
+	},
+	"guild.list": func(ctx context.Context, a Adapter, r *request) (any, error) {
+		return a.GuildList(ctx, r.Next)
+	},
+	"guild.approve": func(ctx context.Context, a Adapter, r *request) (any, error) {
+		return void(a.GuildApprove(ctx, r.MessageId, r.Approve, r.Comment))
+	},
+
+	"guild.member.get": func(ctx context.Context, a Adapter, r *request) (any, error) {
+		return a.GuildMemberGet(ctx, r.GuildId, r.UserId)
+	},
+	"guild.member.list": func(ctx context.Context, a Adapter, r *request) (any, error) {
+		return a.GuildMemberList(ctx, r.GuildId, r.Next)
+	},
+	"guild.member.kick": func(ctx context.Context, a Adapter, r *request) (any, error) {
+		return void(a.GuildMemberKick(ctx, r.GuildId, r.UserId, r.Permanent))
+	},
+	"guild.member.mute": func(ctx context.Context, a Adapter, r *request) (any, error) {
+		return void(a.GuildMemberMute(ctx, r.GuildId, r.UserId, r.Duration))
+	},
+	"guild.member.approve": func(ctx context.Context, a Adapter, r *request) (any, error) {
+		return void(a.GuildMemberApprove(ctx, r.MessageId, r.Approve, r.Comment))
+	},
+	"guild.member.role.set": func(ctx context.Context, a Adapter, r *request) (any, error) {
+		return void(a.GuildMemberRoleSet(ctx, r.GuildId, r.UserId, r.RoleId))
+	},
+	"guild.member.role.unset": func(ctx context.Context, a Adapter, r *request) (any, error) {
+		return void(a.GuildMemberRoleUnset(ctx, r.GuildId, r.UserId, r.RoleId))
+	},
+
+	"guild.role.list": func(ctx context.Context, a Adapter, r *request) (any, error) {
+		return a.GuildRoleList(ctx, r.GuildId, r.Next)
+	},
+	"guild.role.create": func(ctx context.Context, a Adapter, r *request) (any, error) {
+		return a.GuildRoleCreate(ctx, r.GuildId, r.Role)
+	},
+	"guild.role.update": func(ctx context.Context, a Adapter, r *request) (any, error) {
+		return void(a.GuildRoleUpdate(ctx, r.GuildId, r.RoleId, r.Role))
+	},
+	"guild.role.delete": func(ctx context.Context, a Adapter, r *request) (any, error) {
+		return void(a.GuildRoleDelete(ctx, r.GuildId, r.RoleId))
+	},
+
+	"reaction.create": func(ctx context.Context, a Adapter, r *request) (any, error) {
+		return void(a.ReactionCreate(ctx, r.ChannelId, r.MessageId, r.Emoji))
+	},
+	"reaction.delete": func(ctx context.Context, a Adapter, r *request) (any, error) {
+		return void(a.ReactionDelete(ctx, r.ChannelId, r.MessageId, r.Emoji, r.UserId))
+	},
+	"reaction.clear": func(ctx context.Context, a Adapter, r *request) (any, error) {
+		return void(a.ReactionClear(ctx, r.ChannelId, r.MessageId, r.Emoji))
+	},
+	"reaction.list": func(ctx context.Context, a Adapter, r *request) (any, error) {
+		return a.ReactionList(ctx, r.ChannelId, r.MessageId, r.Emoji, r.Next)
+	},
+
+	"login.get": func(ctx context.Context, a Adapter, r *request) (any, error) {
+		return a.LoginGet(ctx)
+	},
+	"user.get": func(ctx context.Context, a Adapter, r *request) (any, error) {
+		return a.UserGet(ctx, r.UserId)
+	},
+	"friend.list": func(ctx context.Context, a Adapter, r *request) (any, error) {
+		return a.FriendList(ctx, r.Next)
+	},
+	"friend.approve": func(ctx context.Context, a Adapter, r *request) (any, error) {
+		return void(a.FriendApprove(ctx, r.MessageId, r.Approve, r.Comment))
+	},
+}
+
+func (s *Server) serveAPI(w http.ResponseWriter, r *http.Request, name string) {
+	ctx := withLogin(r.Context(), r.Header.Get(define.HeaderPlatform), r.Header.Get(define.HeaderUserId))
+
+	var result any
+	var err error
+	if name == "upload.create" {
+		result, err = s.serveUpload(ctx, r)
+	} else {
+		fn, ok := methods[name]
+		if !ok {
+			http.Error(w, "unknown method "+name, http.StatusNotFound)
+			return
+		}
+		var req request
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "malformed request: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		result, err = fn(ctx, s.adapter, &req)
+	}
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if result == nil {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	_ = json.NewEncoder(w).Encode(result)
+}
+
+func (s *Server) serveUpload(ctx context.Context, r *http.Request) (any, error) {
+	if err := r.ParseMultipartForm(s.maxMemory); err != nil {
+		return nil, &ErrStatus{StatusCode: http.StatusBadRequest, Message: "malformed multipart form: " + err.Error()}
+	}
+	defer r.MultipartForm.RemoveAll()
+	files := make(map[string]*define.UploadFile, len(r.MultipartForm.File))
+	for field, headers := range r.MultipartForm.File {
+		if len(headers) == 0 {
+			continue
+		}
+		file, err := headers[0].Open()
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+		files[field] = &define.UploadFile{
+			Name: headers[0].Filename,
+			Type: headers[0].Header.Get("Content-Type"),
+			Data: file,
+		}
+	}
+	return s.adapter.UploadCreate(ctx, files)
+}
+
+// 带有 HTTP 状态码的错误，例如适配器透传的 *client.ErrAPI
+type statusCoder interface {
+	HTTPStatus() int
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	var (
+		statusErr *ErrStatus
+		coder     statusCoder
+	)
+	switch {
+	case errors.Is(err, ErrNotImplemented):
+		http.Error(w, err.Error(), http.StatusMethodNotAllowed)
+	case errors.As(err, &statusErr):
+		http.Error(w, statusErr.Message, statusErr.StatusCode)
+	case errors.As(err, &coder):
+		http.Error(w, err.Error(), coder.HTTPStatus())
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,243 @@
+package server
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/satori-protocol-go/satori-go/pkg/satori/model/event"
+)
+
+// DefaultEventLogCapacity 默认保留的事件数量
+const DefaultEventLogCapacity = 1024
+
+// ErrResync 客户端请求恢复的序列号之后的事件已不再保留，客户端需要重新同步完整状态
+type ErrResync struct {
+	Sn     int64 // 客户端请求恢复的序列号
+	Oldest int64 // 当前保留的最早事件的序列号，没有保留任何事件时为 0
+}
+
+func (e *ErrResync) Error() string {
+	return fmt.Sprintf("satori: events after sn %d are no longer retained (oldest %d)", e.Sn, e.Oldest)
+}
+
+// EventLog 事件日志，为事件分配单调递增的序列号并保留最近的事件，用于客户端恢复会话
+type EventLog interface {
+	// 为事件分配序列号并追加到日志
+	Append(e *event.Event) error
+	// 序列号大于 sn 的全部事件。
+	// 若其中部分事件已不再保留，返回仍保留的事件与 *ErrResync。
+	Since(sn int64) ([]*event.Event, error)
+}
+
+// MemoryLog 保存在内存中的有界事件日志，超出容量时丢弃最早的事件
+type MemoryLog struct {
+	mu     sync.Mutex
+	events []*event.Event // 环形缓冲区
+	start  int
+	size   int
+	sn     int64
+}
+
+// 创建内存事件日志，capacity 不大于 0 时使用 DefaultEventLogCapacity
+func NewMemoryLog(capacity int) *MemoryLog {
+	if capacity <= 0 {
+		capacity = DefaultEventLogCapacity
+	}
+	return &MemoryLog{events: make([]*event.Event, capacity)}
+}
+
+func (l *MemoryLog) Append(e *event.Event) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.sn++
+	e.Sn = l.sn
+	l.push(e)
+	return nil
+}
+
+// 保存已分配序列号的事件，调用方需持有 l.mu
+func (l *MemoryLog) push(e *event.Event) {
+	l.sn = max(l.sn, e.Sn)
+	if l.size < len(l.events) {
+		l.events[(l.start+l.size)%len(l.events)] = e
+		l.size++
+		return
+	}
+	l.events[l.start] = e
+	l.start = (l.start + 1) % len(l.events)
+}
+
+func (l *MemoryLog) Since(sn int64) ([]*event.Event, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	var oldest int64
+	if l.size > 0 {
+		oldest = l.events[l.start].Sn
+	}
+	// 客户端的序列号来自更早的服务端实例，无法确定遗漏了哪些事件
+	if sn > l.sn {
+		return nil, &ErrResync{Sn: sn, Oldest: oldest}
+	}
+	var result []*event.Event
+	for i := range l.size {
+		e := l.events[(l.start+i)%len(l.events)]
+		if e.Sn > sn {
+			result = append(result, e)
+		}
+	}
+	if sn > 0 && sn+1 < oldest {
+		return result, &ErrResync{Sn: sn, Oldest: oldest}
+	}
+	return result, nil
+}
+
+// FileLog 以文件持久化的有界事件日志，重启后可以继续分配序列号并恢复会话。
+// 事件以 JSON Lines 格式追加写入，文件中的事件数超过容量的两倍时会被压缩。
+type FileLog struct {
+	mem     *MemoryLog
+	path    string
+	file    *os.File
+	lines   int
+	err     error
+	partial bool // 文件末尾是否有未以换行结束的内容
+}
+
+// 打开文件事件日志，文件不存在时会被创建，capacity 不大于 0 时使用 DefaultEventLogCapacity
+func OpenFileLog(path string, capacity int) (*FileLog, error) {
+	l := &FileLog{mem: NewMemoryLog(capacity), path: path}
+	if err := l.load(); err != nil {
+		return nil, err
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	l.file = file
+	return l, nil
+}
+
+func (l *FileLog) load() error {
+	file, err := os.Open(l.path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(nil, DefaultMaxMemory)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var e event.Event
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			// 忽略进程中断时写入不完整的最后一行
+			continue
+		}
+		l.mem.push(&e)
+		l.lines++
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	// 进程中断时最后一行可能没有换行，之后追加的事件需要另起一行
+	info, err := file.Stat()
+	if err != nil || info.Size() == 0 {
+		return err
+	}
+	last := make([]byte, 1)
+	if _, err := file.ReadAt(last, info.Size()-1); err != nil {
+		return err
+	}
+	l.partial = last[0] != '\n'
+	return nil
+}
+
+func (l *FileLog) Append(e *event.Event) error {
+	l.mem.mu.Lock()
+	defer l.mem.mu.Unlock()
+	e.Sn = l.mem.sn + 1
+	data, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("satori: encode event: %w", err)
+	}
+	if l.partial {
+		data = append([]byte{'\n'}, data...)
+	}
+	info, err := l.file.Stat()
+	if err != nil {
+		return err
+	}
+	if _, err := l.file.Write(append(data, '\n')); err != nil {
+		// 截断写入不完整的内容，截断失败时在下一条事件前换行，避免两条事件连在同一行
+		l.partial = l.file.Truncate(info.Size()) != nil
+		return err
+	}
+	l.partial = false
+	l.mem.push(e)
+	l.lines++
+	// 事件已经写入，压缩失败只记录错误并在下次追加时重试
+	if l.lines > 2*len(l.mem.events) {
+		l.err = l.compact()
+	}
+	return nil
+}
+
+// 最近一次压缩日志文件的错误，压缩成功后重置为 nil
+func (l *FileLog) Err() error {
+	l.mem.mu.Lock()
+	defer l.mem.mu.Unlock()
+	return l.err
+}
+
+// 只保留内存中的事件重写日志文件，调用方需持有 l.mem.mu
+func (l *FileLog) compact() error {
+	tmp, err := os.CreateTemp(filepath.Dir(l.path), ".satori-events-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	writer := bufio.NewWriter(tmp)
+	encoder := json.NewEncoder(writer)
+	for i := range l.mem.size {
+		if err := encoder.Encode(l.mem.events[(l.mem.start+i)%len(l.mem.events)]); err != nil {
+			tmp.Close()
+			return err
+		}
+	}
+	if err := writer.Flush(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), l.path); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(l.path, os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	l.file.Close()
+	l.file = file
+	l.lines = l.mem.size
+	return nil
+}
+
+func (l *FileLog) Since(sn int64) ([]*event.Event, error) {
+	return l.mem.Since(sn)
+}
+
+// 关闭日志文件
+func (l *FileLog) Close() error {
+	l.mem.mu.Lock()
+	defer l.mem.mu.Unlock()
+	return l.file.Close()
+}
@@ -0,0 +1,216 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/satori-protocol-go/satori-go/pkg/satori/model/event"
+	"github.com/satori-protocol-go/satori-go/pkg/satori/model/operation"
+	"golang.org/x/net/websocket"
+)
+
+// 单个连接最多积压的信令数量，超出时视为客户端过慢并断开连接
+const maxPending = 4096
+
+// 一个已通过鉴权的 WebSocket 连接。
+// 信令先按顺序进入发送队列，再由单独的 goroutine 写出，避免慢速的客户端阻塞其他连接。
+type session struct {
+	conn         *websocket.Conn
+	writeTimeout time.Duration
+
+	mu     sync.Mutex
+	queue  []*operation.Operation
+	closed bool
+	notify chan struct{}
+	done   chan struct{}
+}
+
+func newSession(conn *websocket.Conn, writeTimeout time.Duration) *session {
+	return &session{
+		conn:         conn,
+		writeTimeout: writeTimeout,
+		notify:       make(chan struct{}, 1),
+		done:         make(chan struct{}),
+	}
+}
+
+// 将信令加入发送队列，bounded 为 true 时积压过多会返回 false，连接已关闭时也返回 false
+func (s *session) enqueue(bounded bool, ops ...*operation.Operation) bool {
+	s.mu.Lock()
+	if s.closed || (bounded && len(s.queue)+len(ops) > maxPending) {
+		s.mu.Unlock()
+		return false
+	}
+	s.queue = append(s.queue, ops...)
+	s.mu.Unlock()
+	select {
+	case s.notify <- struct{}{}:
+	default:
+	}
+	return true
+}
+
+// 按顺序写出队列中的信令，写入失败或超时时关闭连接
+func (s *session) run() {
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-s.notify:
+		}
+		s.mu.Lock()
+		ops := s.queue
+		s.queue = nil
+		s.mu.Unlock()
+		for _, op := range ops {
+			if err := s.send(op); err != nil {
+				s.close()
+				return
+			}
+		}
+	}
+}
+
+func (s *session) send(op *operation.Operation) error {
+	if err := s.conn.SetWriteDeadline(time.Now().Add(s.writeTimeout)); err != nil {
+		return err
+	}
+	return websocket.JSON.Send(s.conn, op)
+}
+
+func (s *session) close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return
+	}
+	s.closed = true
+	close(s.done)
+	s.conn.Close()
+}
+
+func (s *Server) serveEvents(w http.ResponseWriter, r *http.Request) {
+	ws := websocket.Server{
+		// Satori 客户端通常不是浏览器，不校验 Origin
+		Handshake: func(*websocket.Config, *http.Request) error { return nil },
+		Handler:   s.handleConn,
+	}
+	ws.ServeHTTP(w, r)
+}
+
+func (s *Server) handleConn(conn *websocket.Conn) {
+	defer conn.Close()
+	ctx := conn.Request().Context()
+
+	if err := conn.SetReadDeadline(time.Now().Add(s.identifyTimeout)); err != nil {
+		return
+	}
+	var op operation.Operation
+	if err := websocket.JSON.Receive(conn, &op); err != nil {
+		return
+	}
+	identify, ok := op.Body.(*operation.IdentifyBody)
+	if op.Op != operation.OpcodeIdentify {
+		return
+	}
+	if !ok {
+		identify = &operation.IdentifyBody{}
+	}
+	if !s.authorized("Bearer " + identify.Token) {
+		return
+	}
+
+	logins, err := s.adapter.Logins(ctx)
+	if err != nil {
+		return
+	}
+	sess := newSession(conn, s.writeTimeout)
+	defer sess.close()
+	ready := &operation.Operation{
+		Op:   operation.OpcodeReady,
+		Body: &operation.ReadyBody{Logins: logins},
+	}
+	// 在锁内将 READY 与补发的事件放入队列并登记连接，保证该连接收到的第一个信令总是 READY 且事件不会遗漏或重复
+	s.mu.Lock()
+	ready.Body.(*operation.ReadyBody).ProxyUrls = s.proxyUrls()
+	ops, err := s.replay(identify.Sn)
+	var resync *ErrResync
+	if err != nil && !errors.As(err, &resync) {
+		s.mu.Unlock()
+		return
+	}
+	sess.enqueue(false, append([]*operation.Operation{ready}, ops...)...)
+	s.sessions[sess] = struct{}{}
+	s.mu.Unlock()
+	if resync != nil && s.onResync != nil {
+		s.onResync(ctx, resync)
+	}
+	defer func() {
+		s.mu.Lock()
+		delete(s.sessions, sess)
+		s.mu.Unlock()
+	}()
+	go sess.run()
+
+	for {
+		if err := conn.SetReadDeadline(time.Now().Add(s.heartbeatTimeout)); err != nil {
+			return
+		}
+		var op operation.Operation
+		if err := websocket.JSON.Receive(conn, &op); err != nil {
+			return
+		}
+		if op.Op == operation.OpcodePing {
+			if !sess.enqueue(true, &operation.Operation{Op: operation.OpcodePong}) {
+				return
+			}
+		}
+	}
+}
+
+// 需要补发的序列号大于 sn 的事件，调用方需持有 s.mu。
+// 遗漏的事件已不再保留时返回仍保留的部分与 *ErrResync。
+func (s *Server) replay(sn int64) ([]*operation.Operation, error) {
+	if s.log == nil || sn == 0 {
+		return nil, nil
+	}
+	events, err := s.log.Since(sn)
+	var resync *ErrResync
+	if err != nil && !errors.As(err, &resync) {
+		return nil, err
+	}
+	ops := make([]*operation.Operation, 0, len(events))
+	for _, e := range events {
+		ops = append(ops, &operation.Operation{Op: operation.OpcodeEvent, Body: e})
+	}
+	return ops, err
+}
+
+// Dispatch 向所有已连接的客户端推送事件。
+// 设置了事件日志时由事件日志分配序列号，否则未设置序列号的事件会被分配一个递增的序列号。
+func (s *Server) Dispatch(e *event.Event) error {
+	s.mu.Lock()
+	if s.log != nil {
+		if err := s.log.Append(e); err != nil {
+			s.mu.Unlock()
+			return err
+		}
+	} else if e.Sn == 0 {
+		e.Sn = s.sn.Add(1)
+	}
+	s.broadcast(&operation.Operation{Op: operation.OpcodeEvent, Body: e})
+	s.mu.Unlock()
+	return nil
+}
+
+// 将信令放入全部连接的发送队列，积压过多的连接会被断开，调用方需持有 s.mu
+func (s *Server) broadcast(op *operation.Operation) {
+	for sess := range s.sessions {
+		if !sess.enqueue(true, op) {
+			delete(s.sessions, sess)
+			go sess.close()
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/satori-protocol-go/satori-go/pkg/satori/internal/proxyurl"
+	"github.com/satori-protocol-go/satori-go/pkg/satori/model/operation"
+)
+
+type proxyRoute struct {
+	prefix    string
+	base      *url.URL
+	transport http.RoundTripper
+}
+
+func (p *proxyRoute) match(target *url.URL) bool {
+	return proxyurl.Match(p.base, target)
+}
+
+// AddProxy 注册代理路由前缀，并向已连接的客户端推送 META 信令。
+// 匹配该前缀的 URL 可以通过 /v1/proxy/{url} 访问，transport 用于附加平台鉴权等信息，为 nil 时使用 http.DefaultTransport。
+// prefix 必须是带 scheme 与 host 的绝对 URL，否则不会匹配任何请求。
+func (s *Server) AddProxy(prefix string, transport http.RoundTripper) {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	base, _ := proxyurl.ParsePrefix(prefix)
+	s.mu.Lock()
+	s.proxies = slices.DeleteFunc(s.proxies, func(p proxyRoute) bool { return p.prefix == prefix })
+	s.proxies = append(s.proxies, proxyRoute{prefix: prefix, base: base, transport: transport})
+	s.mu.Unlock()
+	s.broadcastMeta()
+}
+
+// RemoveProxy 移除代理路由前缀，并向已连接的客户端推送 META 信令
+func (s *Server) RemoveProxy(prefix string) {
+	s.mu.Lock()
+	s.proxies = slices.DeleteFunc(s.proxies, func(p proxyRoute) bool { return p.prefix == prefix })
+	s.mu.Unlock()
+	s.broadcastMeta()
+}
+
+// ProxyUrls 当前注册的代理路由前缀
+func (s *Server) ProxyUrls() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.proxyUrls()
+}
+
+func (s *Server) proxyUrls() []string {
+	urls := make([]string, 0, len(s.proxies))
+	for _, p := range s.proxies {
+		urls = append(urls, p.prefix)
+	}
+	return urls
+}
+
+func (s *Server) broadcastMeta() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.broadcast(&operation.Operation{Op: operation.OpcodeMeta, Body: &operation.MetaBody{ProxyUrls: s.proxyUrls()}})
+}
+
+// 转发 /v1/proxy/{url} 请求，只转发匹配已注册前缀的 URL
+func (s *Server) serveProxy(w http.ResponseWriter, r *http.Request, raw string) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	// 兼容客户端对目标 URL 整体进行了百分号编码的情况
+	if !strings.Contains(raw, "://") {
+		if unescaped, err := url.PathUnescape(raw); err == nil {
+			raw = unescaped
+		}
+	}
+	if r.URL.RawQuery != "" {
+		raw += "?" + r.URL.RawQuery
+	}
+	target, err := url.Parse(raw)
+	if err != nil || target.Scheme == "" || target.Host == "" {
+		http.Error(w, "malformed proxy url", http.StatusBadRequest)
+		return
+	}
+
+	// 复制匹配的路由，s.proxies 在解锁后可能被 AddProxy 与 RemoveProxy 原地修改
+	var route *proxyRoute
+	s.mu.Lock()
+	for _, p := range s.proxies {
+		if p.match(target) {
+			route = &p
+			break
+		}
+	}
+	s.mu.Unlock()
+	if route == nil {
+		http.Error(w, "proxy url not allowed", http.StatusForbidden)
+		return
+	}
+
+	proxy := &httputil.ReverseProxy{
+		Rewrite: func(pr *httputil.ProxyRequest) {
+			pr.Out.URL = target
+			pr.Out.Host = target.Host
+			pr.Out.Header.Del("Authorization")
+			pr.Out.Header.Del("Cookie")
+		},
+		Transport: route.transport,
+	}
+	proxy.ServeHTTP(w, r)
+}
@@ -0,0 +1,141 @@
+package server
+
+import (
+	"context"
+	"crypto/subtle"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	DefaultMaxMemory        = 32 << 20         // 默认上传文件时使用的内存上限
+	DefaultHeartbeatTimeout = 30 * time.Second // 默认心跳超时时间
+	DefaultIdentifyTimeout  = 10 * time.Second // 默认等待 IDENTIFY 信令的时间
+	DefaultWriteTimeout     = 10 * time.Second // 默认推送信令的写入超时时间
+)
+
+// Option 服务端配置项
+type Option func(*Server)
+
+// 设置鉴权令牌，为空时不校验鉴权
+func WithToken(token string) Option {
+	return func(s *Server) {
+		s.token = token
+	}
+}
+
+// 设置心跳超时时间，超过该时间未收到客户端的信令时断开连接
+func WithHeartbeatTimeout(timeout time.Duration) Option {
+	return func(s *Server) {
+		s.heartbeatTimeout = timeout
+	}
+}
+
+// 设置推送信令的写入超时时间，超时的连接会被断开
+func WithWriteTimeout(timeout time.Duration) Option {
+	return func(s *Server) {
+		s.writeTimeout = timeout
+	}
+}
+
+// 设置事件日志，用于为事件分配序列号并在客户端重连时补发遗漏的事件
+func WithEventLog(log EventLog) Option {
+	return func(s *Server) {
+		s.log = log
+	}
+}
+
+// 设置无法补发遗漏事件时的回调。
+// 客户端请求恢复的事件已不再保留时调用，协议中没有通知客户端重新同步的信令，
+// 客户端只能在收到下一个事件时根据序列号不连续自行发现。
+func WithResyncHandler(fn func(ctx context.Context, err *ErrResync)) Option {
+	return func(s *Server) {
+		s.onResync = fn
+	}
+}
+
+// Server 将 Adapter 暴露为 Satori 服务，提供 /v1/{method} HTTP API、/v1/events WebSocket 事件推送与 /v1/proxy/{url} 代理路由。
+// 代理路由不校验鉴权，只转发匹配已注册前缀的 URL。
+// http.ServeMux 会清理路径中的连续斜杠并重定向（https://a.com 变为 https:/a.com），
+// 挂载在 ServeMux 下时客户端需要对目标 URL 整体进行百分号编码，或将 Server 作为根 Handler 使用。
+type Server struct {
+	adapter          Adapter
+	token            string
+	maxMemory        int64
+	heartbeatTimeout time.Duration
+	identifyTimeout  time.Duration
+	writeTimeout     time.Duration
+	log              EventLog
+	onResync         func(ctx context.Context, err *ErrResync)
+
+	sn       atomic.Int64
+	mu       sync.Mutex
+	sessions map[*session]struct{}
+	proxies  []proxyRoute
+}
+
+func New(adapter Adapter, opts ...Option) *Server {
+	s := &Server{
+		adapter:          adapter,
+		maxMemory:        DefaultMaxMemory,
+		heartbeatTimeout: DefaultHeartbeatTimeout,
+		identifyTimeout:  DefaultIdentifyTimeout,
+		writeTimeout:     DefaultWriteTimeout,
+		sessions:         make(map[*session]struct{}),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	name, ok := strings.CutPrefix(r.URL.Path, "/v1/")
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	if name == "events" {
+		s.serveEvents(w, r)
+		return
+	}
+	if strings.HasPrefix(name, "proxy/") {
+		raw, _ := strings.CutPrefix(r.URL.EscapedPath(), "/v1/proxy/")
+		s.serveProxy(w, r, raw)
+		return
+	}
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if !s.authorized(r.Header.Get("Authorization")) {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	s.serveAPI(w, r, name)
+}
+
+func (s *Server) authorized(authorization string) bool {
+	return s.token == "" || subtle.ConstantTimeCompare([]byte(authorization), []byte("Bearer "+s.token)) == 1
+}
+
+type loginKey struct{}
+
+type loginValue struct {
+	platform string
+	userId   string
+}
+
+func withLogin(ctx context.Context, platform, userId string) context.Context {
+	return context.WithValue(ctx, loginKey{}, loginValue{platform: platform, userId: userId})
+}
+
+// LoginFromContext 获取当前 API 请求的平台名称与平台账号
+func LoginFromContext(ctx context.Context) (platform, userId string) {
+	value, _ := ctx.Value(loginKey{}).(loginValue)
+	return value.platform, value.userId
+}
@@ -36,7 +36,7 @@ type fallbackTarget struct {
 
 func TestBindAttrs(t *testing.T) {
 	dst := &bindTarget{}
-	err := attrbind.BindAttrs(dst, map[string]any{
+	err := attrbind.UnmarshalAttrs(dst, map[string]any{
 		"id":      "12",
 		"title":   123,
 		"enabled": "true",
@@ -87,28 +87,28 @@ func TestBindAttrs(t *testing.T) {
 
 func TestBindAttrsErrors(t *testing.T) {
 	var nilPtr *bindTarget
-	if err := attrbind.BindAttrs(nilPtr, map[string]any{"id": 1}); err == nil {
+	if err := attrbind.UnmarshalAttrs(nilPtr, map[string]any{"id": 1}); err == nil {
 		t.Fatalf("expected nil pointer error")
 	}
-	if err := attrbind.BindAttrs(bindTarget{}, map[string]any{"id": 1}); err == nil {
+	if err := attrbind.UnmarshalAttrs(bindTarget{}, map[string]any{"id": 1}); err == nil {
 		t.Fatalf("expected non-pointer error")
 	}
-	if err := attrbind.BindAttrs(&bindTarget{}, map[string]any{"id": "bad-int"}); err == nil {
+	if err := attrbind.UnmarshalAttrs(&bindTarget{}, map[string]any{"id": "bad-int"}); err == nil {
 		t.Fatalf("expected conversion error")
 	}
-	if err := attrbind.BindAttrs(&requiredTarget{}, map[string]any{}); err == nil {
+	if err := attrbind.UnmarshalAttrs(&requiredTarget{}, map[string]any{}); err == nil {
 		t.Fatalf("expected missing required error")
 	}
 }
 
 func TestBindAttrsOptionalAndFallback(t *testing.T) {
 	optional := &optionalTarget{}
-	if err := attrbind.BindAttrs(optional, map[string]any{}); err != nil {
+	if err := attrbind.UnmarshalAttrs(optional, map[string]any{}); err != nil {
 		t.Fatalf("optional field should not error: %v", err)
 	}
 
 	fallback := &fallbackTarget{}
-	if err := attrbind.BindAttrs(fallback, map[string]any{"value": "ok"}); err != nil {
+	if err := attrbind.UnmarshalAttrs(fallback, map[string]any{"value": "ok"}); err != nil {
 		t.Fatalf("fallback field key should bind: %v", err)
 	}
 	if fallback.Value != "ok" {
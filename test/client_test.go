@@ -0,0 +1,316 @@
+package testsuite
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/satori-protocol-go/satori-go/pkg/satori/client"
+	"github.com/satori-protocol-go/satori-go/pkg/satori/model/channel"
+	"github.com/satori-protocol-go/satori-go/pkg/satori/model/event"
+	"github.com/satori-protocol-go/satori-go/pkg/satori/model/guildrole"
+	"github.com/satori-protocol-go/satori-go/pkg/satori/model/login"
+	"github.com/satori-protocol-go/satori-go/pkg/satori/model/message"
+)
+
+type recordedCall struct {
+	Path   string
+	Header http.Header
+	Body   map[string]any
+}
+
+func newAPIServer(t *testing.T, responses map[string]string) (*httptest.Server, *[]recordedCall) {
+	t.Helper()
+	calls := make([]recordedCall, 0)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		body := map[string]any{}
+		_ = json.Unmarshal(data, &body)
+		calls = append(calls, recordedCall{Path: r.URL.Path, Header: r.Header.Clone(), Body: body})
+		response, ok := responses[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, response)
+	}))
+	t.Cleanup(server.Close)
+	return server, &calls
+}
+
+func TestClientMessage(t *testing.T) {
+	server, calls := newAPIServer(t, map[string]string{
+		"/v1/message.create": `[{"id":"m1","content":"hi"}]`,
+		"/v1/message.list":   `{"data":[{"id":"m1","content":"hi"}],"prev":"p","next":"n"}`,
+		"/v1/message.delete": ``,
+	})
+	c := client.New(server.URL+"/", client.WithToken("secret")).WithLogin("discord", "42")
+	ctx := context.Background()
+
+	created, err := c.MessageCreate(ctx, "c1", "hi")
+	if err != nil {
+		t.Fatalf("MessageCreate failed: %v", err)
+	}
+	if len(created) != 1 || created[0].Id != "m1" {
+		t.Fatalf("MessageCreate result mismatch: %+v", created)
+	}
+	first := (*calls)[0]
+	if first.Header.Get("Authorization") != "Bearer secret" {
+		t.Fatalf("authorization header mismatch: %q", first.Header.Get("Authorization"))
+	}
+	if first.Header.Get("Satori-Platform") != "discord" || first.Header.Get("Satori-User-ID") != "42" {
+		t.Fatalf("login headers mismatch: %v", first.Header)
+	}
+	if first.Body["channel_id"] != "c1" || first.Body["content"] != "hi" {
+		t.Fatalf("MessageCreate body mismatch: %v", first.Body)
+	}
+
+	list, err := c.MessageList(ctx, "c1", "", client.DirectionBefore, 10, "")
+	if err != nil {
+		t.Fatalf("MessageList failed: %v", err)
+	}
+	if len(list.Data) != 1 || list.Prev != "p" || list.Next != "n" {
+		t.Fatalf("MessageList result mismatch: %+v", list)
+	}
+	if body := (*calls)[1].Body; body["direction"] != "before" || body["limit"] != float64(10) {
+		t.Fatalf("MessageList body mismatch: %v", body)
+	}
+	if _, ok := (*calls)[1].Body["order"]; ok {
+		t.Fatalf("empty order should be omitted: %v", (*calls)[1].Body)
+	}
+
+	if err := c.MessageDelete(ctx, "c1", "m1"); err != nil {
+		t.Fatalf("MessageDelete failed: %v", err)
+	}
+	if _, err := c.MessageGet(ctx, "c1", "m1"); err == nil {
+		t.Fatalf("MessageGet should fail on 404")
+	}
+}
+
+func TestClientErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/message.get":
+			http.Error(w, "no such message", http.StatusNotFound)
+		case "/v1/channel.mute":
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		default:
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	}))
+	t.Cleanup(server.Close)
+	c := client.New(server.URL).WithLogin("qq", "10001")
+	ctx := context.Background()
+
+	_, err := c.MessageGet(ctx, "c1", "m1")
+	if !errors.Is(err, &client.ErrNotFound{}) {
+		t.Fatalf("expected ErrNotFound, got: %v", err)
+	}
+	if errors.Is(err, &client.ErrForbidden{}) {
+		t.Fatalf("ErrNotFound should not match ErrForbidden")
+	}
+	var apiErr *client.ErrAPI
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected ErrAPI, got: %T", err)
+	}
+	if apiErr.Method != "message.get" || apiErr.Platform != "qq" || apiErr.UserId != "10001" || !strings.Contains(string(apiErr.Body), "no such message") {
+		t.Fatalf("ErrAPI fields mismatch: %+v", apiErr)
+	}
+	if apiErr.Temporary() {
+		t.Fatalf("404 should not be temporary")
+	}
+
+	var unsupported *client.ErrMethodNotAllowed
+	if err := c.ChannelMute(ctx, "c1", 0); !errors.As(err, &unsupported) {
+		t.Fatalf("expected ErrMethodNotAllowed, got: %v", err)
+	}
+
+	err = c.GuildApprove(ctx, "req1", true, "")
+	if !errors.Is(err, &client.ErrServerError{}) || !errors.As(err, &apiErr) || !apiErr.Temporary() {
+		t.Fatalf("expected temporary ErrServerError, got: %v", err)
+	}
+}
+
+func TestClientChannel(t *testing.T) {
+	server, calls := newAPIServer(t, map[string]string{
+		"/v1/channel.list":        `{"data":[{"id":"c1","type":0,"name":"general"}],"next":"n"}`,
+		"/v1/user.channel.create": `{"id":"dm1","type":1}`,
+		"/v1/channel.mute":        ``,
+	})
+	c := client.New(server.URL).WithLogin("discord", "42")
+	ctx := context.Background()
+
+	list, err := c.ChannelList(ctx, "g1", "")
+	if err != nil {
+		t.Fatalf("ChannelList failed: %v", err)
+	}
+	if len(list.Data) != 1 || list.Data[0].Name != "general" || list.Next != "n" {
+		t.Fatalf("ChannelList result mismatch: %+v", list)
+	}
+
+	direct, err := c.UserChannelCreate(ctx, "u1", "")
+	if err != nil {
+		t.Fatalf("UserChannelCreate failed: %v", err)
+	}
+	if direct.Id != "dm1" || direct.Type != channel.ChannelTypeDirect {
+		t.Fatalf("UserChannelCreate result mismatch: %+v", direct)
+	}
+	if body := (*calls)[1].Body; body["user_id"] != "u1" || body["guild_id"] != nil {
+		t.Fatalf("UserChannelCreate body mismatch: %v", body)
+	}
+
+	if err := c.ChannelMute(ctx, "c1", 0); err != nil {
+		t.Fatalf("ChannelMute failed: %v", err)
+	}
+	if body := (*calls)[2].Body; body["duration"] != float64(0) {
+		t.Fatalf("ChannelMute should send zero duration: %v", body)
+	}
+}
+
+func TestClientGuild(t *testing.T) {
+	server, calls := newAPIServer(t, map[string]string{
+		"/v1/guild.member.list":     `{"data":[{"user":{"id":"u1"},"nick":"neo"}]}`,
+		"/v1/guild.member.kick":     ``,
+		"/v1/guild.member.approve":  ``,
+		"/v1/guild.member.role.set": ``,
+		"/v1/guild.role.create":     `{"id":"r1","name":"admin"}`,
+	})
+	c := client.New(server.URL).WithLogin("discord", "42")
+	ctx := context.Background()
+
+	members, err := c.GuildMemberList(ctx, "g1", "")
+	if err != nil {
+		t.Fatalf("GuildMemberList failed: %v", err)
+	}
+	if len(members.Data) != 1 || members.Data[0].User.Id != "u1" || members.Next != "" {
+		t.Fatalf("GuildMemberList result mismatch: %+v", members)
+	}
+	if err := c.GuildMemberKick(ctx, "g1", "u1", true); err != nil {
+		t.Fatalf("GuildMemberKick failed: %v", err)
+	}
+	if body := (*calls)[1].Body; body["permanent"] != true || body["user_id"] != "u1" {
+		t.Fatalf("GuildMemberKick body mismatch: %v", body)
+	}
+	if err := c.GuildMemberApprove(ctx, "req1", false, "no"); err != nil {
+		t.Fatalf("GuildMemberApprove failed: %v", err)
+	}
+	if body := (*calls)[2].Body; body["message_id"] != "req1" || body["approve"] != false || body["comment"] != "no" {
+		t.Fatalf("GuildMemberApprove body mismatch: %v", body)
+	}
+	if err := c.GuildMemberRoleSet(ctx, "g1", "u1", "r1"); err != nil {
+		t.Fatalf("GuildMemberRoleSet failed: %v", err)
+	}
+
+	role, err := c.GuildRoleCreate(ctx, "g1", &guildrole.GuildRole{Name: "admin"})
+	if err != nil {
+		t.Fatalf("GuildRoleCreate failed: %v", err)
+	}
+	if role.Id != "r1" {
+		t.Fatalf("GuildRoleCreate result mismatch: %+v", role)
+	}
+	if body := (*calls)[4].Body; body["role"].(map[string]any)["name"] != "admin" {
+		t.Fatalf("GuildRoleCreate body mismatch: %v", body)
+	}
+}
+
+func TestClientReaction(t *testing.T) {
+	server, calls := newAPIServer(t, map[string]string{
+		"/v1/reaction.list":  `{"data":[{"id":"u1","name":"neo"}],"next":"n"}`,
+		"/v1/reaction.clear": ``,
+	})
+	c := client.New(server.URL).WithLogin("discord", "42")
+	ctx := context.Background()
+
+	users, err := c.ReactionList(ctx, "c1", "m1", "👍", "")
+	if err != nil {
+		t.Fatalf("ReactionList failed: %v", err)
+	}
+	if len(users.Data) != 1 || users.Data[0].Name != "neo" || users.Next != "n" {
+		t.Fatalf("ReactionList result mismatch: %+v", users)
+	}
+	if err := c.ReactionClear(ctx, "c1", "m1", ""); err != nil {
+		t.Fatalf("ReactionClear failed: %v", err)
+	}
+	if _, ok := (*calls)[1].Body["emoji"]; ok {
+		t.Fatalf("empty emoji should be omitted: %v", (*calls)[1].Body)
+	}
+
+	var e event.Event
+	if err := json.Unmarshal([]byte(`{"sn":1,"type":"reaction-added","timestamp":0,"emoji":{"id":"123","name":"thumbsup"}}`), &e); err != nil {
+		t.Fatalf("decode reaction event failed: %v", err)
+	}
+	if e.Type != event.EventTypeReactionAdded || e.Emoji == nil || e.Emoji.Id != "123" {
+		t.Fatalf("reaction event emoji mismatch: %+v", e.Emoji)
+	}
+}
+
+func TestClientLoginAndFriend(t *testing.T) {
+	server, calls := newAPIServer(t, map[string]string{
+		"/v1/login.get":      `{"sn":1,"platform":"discord","user":{"id":"42"},"status":1,"adapter":"discord"}`,
+		"/v1/friend.approve": ``,
+	})
+	c := client.New(server.URL).WithLogin("discord", "42")
+	ctx := context.Background()
+
+	l, err := c.LoginGet(ctx)
+	if err != nil {
+		t.Fatalf("LoginGet failed: %v", err)
+	}
+	if l.Platform != "discord" || l.User.Id != "42" || l.Status != login.LoginStatusOnline {
+		t.Fatalf("LoginGet result mismatch: %+v", l)
+	}
+
+	request := event.Event{Type: event.EventTypeFriendRequest, Message: &message.Message{Id: "req1"}}
+	if err := c.FriendApprove(ctx, request.Message.Id, true, ""); err != nil {
+		t.Fatalf("FriendApprove failed: %v", err)
+	}
+	if body := (*calls)[1].Body; body["message_id"] != "req1" || body["approve"] != true {
+		t.Fatalf("FriendApprove body mismatch: %v", body)
+	}
+}
+
+func TestClientInternal(t *testing.T) {
+	server, calls := newAPIServer(t, map[string]string{
+		"/v1/internal/group.file.list": `{"files":[{"name":"a.txt"},{"name":"b.txt"}]}`,
+	})
+	c := client.New(server.URL, client.WithToken("secret")).WithLogin("qq", "10001")
+	ctx := context.Background()
+
+	var result struct {
+		Files []struct {
+			Name string `json:"name"`
+		} `json:"files"`
+	}
+	if err := c.Internal(ctx, "group.file.list", map[string]any{"group_id": "1"}, &result); err != nil {
+		t.Fatalf("Internal failed: %v", err)
+	}
+	if len(result.Files) != 2 || result.Files[1].Name != "b.txt" {
+		t.Fatalf("Internal result mismatch: %+v", result)
+	}
+	first := (*calls)[0]
+	if first.Header.Get("Satori-Platform") != "qq" || first.Header.Get("Authorization") != "Bearer secret" || first.Body["group_id"] != "1" {
+		t.Fatalf("Internal request mismatch: %+v", first)
+	}
+
+	stream, err := c.InternalStream(ctx, "group.file.list", nil)
+	if err != nil {
+		t.Fatalf("InternalStream failed: %v", err)
+	}
+	defer stream.Close()
+	raw, _ := io.ReadAll(stream)
+	if !strings.Contains(string(raw), `"b.txt"`) {
+		t.Fatalf("InternalStream body mismatch: %s", raw)
+	}
+
+	err = c.Internal(ctx, "missing", nil, nil)
+	var apiErr *client.ErrAPI
+	if !errors.Is(err, &client.ErrNotFound{}) || !errors.As(err, &apiErr) || apiErr.Method != "internal/missing" {
+		t.Fatalf("Internal should return typed errors, got: %v", err)
+	}
+}
@@ -0,0 +1,620 @@
+package testsuite
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/satori-protocol-go/satori-go/pkg/satori/model/message"
+	"github.com/satori-protocol-go/satori-go/pkg/satori/model/message/element"
+)
+
+func TestElementParse(t *testing.T) {
+	msg := &message.Message{Content: `<quote id="q1"/><at id="1" name="neo"/> hi &amp; bye<img src="https://example.com/a.png" width="10"/><b>bold</b><custom foo="bar">x</custom>`}
+	elements, err := msg.Elements()
+	if err != nil {
+		t.Fatalf("Elements failed: %v", err)
+	}
+	if len(elements) != 6 {
+		t.Fatalf("Elements len mismatch: %d", len(elements))
+	}
+	if quote, ok := elements[0].(*element.Quote); !ok || quote.Id != "q1" {
+		t.Fatalf("quote mismatch: %#v", elements[0])
+	}
+	if at, ok := elements[1].(*element.At); !ok || at.Id != "1" || at.Name != "neo" {
+		t.Fatalf("at mismatch: %#v", elements[1])
+	}
+	if text, ok := elements[2].(*element.Text); !ok || text.Text != " hi & bye" {
+		t.Fatalf("text mismatch: %#v", elements[2])
+	}
+	if img, ok := elements[3].(*element.Img); !ok || img.Src != "https://example.com/a.png" || img.Width != 10 {
+		t.Fatalf("img mismatch: %#v", elements[3])
+	}
+	bold, ok := elements[4].(*element.Strong)
+	if !ok || len(bold.Children()) != 1 {
+		t.Fatalf("bold mismatch: %#v", elements[4])
+	}
+	if text, ok := bold.Children()[0].(*element.Text); !ok || text.Text != "bold" {
+		t.Fatalf("bold child mismatch: %#v", bold.Children()[0])
+	}
+	if custom, ok := elements[5].(*element.Extension); !ok || custom.Tag() != "custom" {
+		t.Fatalf("extension mismatch: %#v", elements[5])
+	} else if foo, _ := custom.Get("foo"); foo != "bar" {
+		t.Fatalf("extension attr mismatch: %v", foo)
+	}
+
+	// 修饰元素保留其中的任意子元素
+	source := `<b>hi <at id="1"/> <a href="http://x">l</a><br/></b><i><img src="x"/></i>`
+	nested, err := element.Parse(source)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if got := element.Marshal(nested...); got != source {
+		t.Fatalf("nested decorative mismatch:\n got: %s\nwant: %s", got, source)
+	}
+	if at, ok := nested[0].Children()[1].(*element.At); !ok || at.Id != "1" {
+		t.Fatalf("nested at mismatch: %#v", nested[0].Children()[1])
+	}
+	if a, ok := nested[0].Children()[3].(*element.A); !ok || a.Href != "http://x" {
+		t.Fatalf("nested link mismatch: %#v", nested[0].Children()[3])
+	}
+}
+
+func TestElementMarshal(t *testing.T) {
+	content := element.Marshal(
+		element.Reply("m1"),
+		element.AtUser("1"),
+		element.AtAll(),
+		element.Plain(`a<b & "c"`),
+		element.Image(`https://example.com/a.png?x=1&y="2"`),
+		element.Bold(element.Plain("x"), element.Italic(element.Plain("y"))),
+		element.Link("https://example.com", element.Plain("site")),
+	)
+	want := `<quote id="m1"/><at id="1"/><at type="all"/>a&lt;b &amp; "c"` +
+		`<img src="https://example.com/a.png?x=1&amp;y=&quot;2&quot;"/>` +
+		`<b>x<i>y</i></b><a href="https://example.com">site</a>`
+	if content != want {
+		t.Fatalf("Marshal mismatch:\n got: %s\nwant: %s", content, want)
+	}
+
+	elements, err := element.Parse(content)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if again := element.Marshal(elements...); again != content {
+		t.Fatalf("round trip mismatch:\n got: %s\nwant: %s", again, content)
+	}
+	if img, ok := elements[4].(*element.Img); !ok || img.Src != `https://example.com/a.png?x=1&y="2"` {
+		t.Fatalf("img src mismatch: %#v", elements[4])
+	}
+
+	// 修饰元素的构造函数保留全部子元素
+	decorated := element.Marshal(
+		element.Bold(element.AtUser("1"), element.Plain("x"), element.Link("http://a")),
+		element.Spoiler(element.Image("a.png"), element.Newline()),
+	)
+	if want := `<b><at id="1"/>x<a href="http://a"/></b><spl><img src="a.png"/><br/></spl>`; decorated != want {
+		t.Fatalf("decorative builder mismatch:\n got: %s\nwant: %s", decorated, want)
+	}
+}
+
+func TestRenderText(t *testing.T) {
+	elements, err := element.Parse(`<quote id="q"><p>quoted</p></quote><p>hi <at id="1" name="neo"/> in <sharp id="c1"/></p>` +
+		`<p>see <a href="https://example.com">docs</a> and <a href="https://example.com"/></p>line<br/>next<img src="https://example.com/a.png"/>`)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	want := "hi @neo in #c1\nsee docs (https://example.com) and https://example.com\nline\nnext[image]"
+	if got := element.RenderText(elements); got != want {
+		t.Fatalf("RenderText mismatch:\n got: %q\nwant: %q", got, want)
+	}
+
+	got := element.RenderText(elements,
+		element.WithAtText(func(at *element.At) string { return "<@" + at.Id + ">" }),
+		element.WithSharpText(func(sharp *element.Sharp) string { return "<#" + sharp.Id + ">" }),
+		element.WithResourceText(func(res element.ResourceElement) string { return res.GetResource().Src }),
+		element.WithLinkText(func(a *element.A, text string) string { return "[" + text + "](" + a.Href + ")" }),
+		element.WithQuoteText(func(quote *element.Quote, text string) string { return "> " + text }),
+	)
+	want = "> quoted\nhi <@1> in <#c1>\nsee [docs](https://example.com) and [](https://example.com)\nline\nnexthttps://example.com/a.png"
+	if got != want {
+		t.Fatalf("RenderText with options mismatch:\n got: %q\nwant: %q", got, want)
+	}
+}
+
+func TestMarkdown(t *testing.T) {
+	discord := element.WithDiscordDialect()
+	cases := []struct {
+		name     string
+		elements []element.Element
+		markdown string
+		opts     []element.MarkdownOption
+	}{
+		{
+			name:     "escape",
+			elements: []element.Element{element.Plain("2*3 = a_b `x` ~y~ [z] <w> |v|")},
+			markdown: `2\*3 = a\_b \` + "`" + `x\` + "`" + ` \~y\~ \[z\] \<w\> \|v\|`,
+		},
+		{
+			name: "inline",
+			elements: []element.Element{
+				element.Bold(element.Plain("b "), element.Italic(element.Plain("i"))),
+				element.Plain(" "),
+				element.Strike(element.Plain("s")),
+				element.Plain(" "),
+				element.Monospace(element.Plain("a`b")),
+				element.Plain(" "),
+				element.Link("https://example.com", element.Plain("site")),
+				element.Plain(" "),
+				element.Link("https://example.com/raw"),
+				element.Newline(),
+				element.Image("https://example.com/a.png"),
+			},
+			markdown: "**b *i*** ~~s~~ ``a`b`` [site](https://example.com) <https://example.com/raw>\n![](https://example.com/a.png)",
+		},
+		{
+			name: "em around strong",
+			elements: []element.Element{
+				element.Italic(element.Bold(element.Plain("b")), element.Plain(" i")),
+			},
+			markdown: "_**b** i_",
+		},
+		{
+			name: "dialect",
+			elements: []element.Element{
+				element.Spoiler(element.Plain("secret")),
+				element.Plain(" "),
+				element.Underline(element.Plain("under")),
+				element.Plain(" "),
+				element.Bold(element.Plain("bold")),
+			},
+			markdown: "||secret|| __under__ **bold**",
+			opts:     []element.MarkdownOption{discord},
+		},
+		{
+			name: "link parentheses",
+			elements: []element.Element{
+				element.Link("https://en.wikipedia.org/wiki/Go_(programming_language)", element.Plain("go")),
+				element.Plain(" "),
+				element.Link("http://a.com/x)", element.Plain("u")),
+			},
+			markdown: `[go](https://en.wikipedia.org/wiki/Go_(programming_language)) [u](http://a.com/x\))`,
+		},
+		{
+			name: "adjacent emphasis",
+			elements: []element.Element{
+				element.Bold(element.Plain("a")),
+				element.Bold(element.Plain("b")),
+				element.Italic(element.Plain("c")),
+				element.Italic(element.Plain("d")),
+				element.Strike(element.Plain("e")),
+				element.Strike(element.Plain("f")),
+			},
+			markdown: "**a**<!---->**b**<!---->*c*<!---->*d*~~e~~<!---->~~f~~",
+		},
+		{
+			name: "bold before italic and word",
+			elements: []element.Element{
+				element.Bold(element.Plain("a")),
+				element.Italic(element.Plain("b")),
+				element.Plain("c"),
+			},
+			markdown: "**a**<!---->*b*c",
+		},
+		{
+			name: "adjacent bold before word",
+			elements: []element.Element{
+				element.Bold(element.Plain("a")),
+				element.Bold(element.Plain("b")),
+				element.Plain("c"),
+			},
+			markdown: "**a**<!---->**b**c",
+		},
+		{
+			name: "em around strong between words",
+			elements: []element.Element{
+				element.Plain("x"),
+				element.Italic(element.Bold(element.Plain("a"))),
+				element.Plain("c"),
+			},
+			markdown: "x<!---->_**a**_<!---->c",
+		},
+		{
+			name: "escaped delimiter before emphasis",
+			elements: []element.Element{
+				element.Plain("5*"),
+				element.Italic(element.Plain("b")),
+				element.Plain("c"),
+			},
+			markdown: `5\**b*c`,
+		},
+		{
+			name: "adjacent emphasis dialect",
+			elements: []element.Element{
+				element.Bold(element.Plain("a")),
+				element.Italic(element.Plain("c")),
+				element.Spoiler(element.Plain("e")),
+				element.Spoiler(element.Plain("f")),
+				element.Underline(element.Plain("g")),
+				element.Underline(element.Plain("h")),
+				element.Plain("i"),
+			},
+			markdown: "**a**<!---->*c*||e||<!---->||f||__g__<!---->__h__<!---->i",
+			opts:     []element.MarkdownOption{discord},
+		},
+		{
+			name: "bang before link",
+			elements: []element.Element{
+				element.Plain("!"),
+				element.Link("https://a", element.Plain("b")),
+			},
+			markdown: `\![b](https://a)`,
+		},
+		{
+			name: "blocks",
+			elements: []element.Element{
+				element.Paragraph(element.Plain("first"), element.Newline(), element.Plain("line")),
+				newElement[*element.Quote](t, element.Plain("quoted "), element.Bold(element.Plain("text"))),
+				element.Paragraph(element.Monospace(element.Plain("fn main() {\n}"))),
+			},
+			markdown: "first\nline\n\n> quoted **text**\n\n```\nfn main() {\n}\n```",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := element.MarshalMarkdown(tc.elements, tc.opts...); got != tc.markdown {
+				t.Fatalf("MarshalMarkdown mismatch:\n got: %q\nwant: %q", got, tc.markdown)
+			}
+			parsed := element.ParseMarkdown(tc.markdown, tc.opts...)
+			if got, want := element.Marshal(parsed...), element.Marshal(tc.elements...); got != want {
+				t.Fatalf("ParseMarkdown mismatch:\n got: %s\nwant: %s", got, want)
+			}
+		})
+	}
+
+	// 未设置方言时剧透与下划线只保留内容，__ 表示粗体
+	if got := element.MarshalMarkdown([]element.Element{element.Spoiler(element.Plain("s")), element.Underline(element.Plain("u"))}); got != "su" {
+		t.Fatalf("default dialect mismatch: %q", got)
+	}
+	if got := element.Marshal(element.ParseMarkdown("__b__ snake_case_name")...); got != "<b>b</b> snake_case_name" {
+		t.Fatalf("default dialect parse mismatch: %s", got)
+	}
+
+	// 强调中的链接与换行会被保留
+	want := `<b><a href="http://x.com">click</a></b> and <b>a<br/>b</b>`
+	if got := element.Marshal(element.ParseMarkdown("**[click](http://x.com)** and **a\nb**")...); got != want {
+		t.Fatalf("nested emphasis parse mismatch: %s", got)
+	}
+	parsed, err := element.Parse(element.Marshal(element.ParseMarkdown("**hi [l](http://x)**")...))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if got := element.Marshal(parsed...); got != `<b>hi <a href="http://x">l</a></b>` {
+		t.Fatalf("nested emphasis round trip mismatch: %s", got)
+	}
+	// 无法作为自动链接的地址作为链接的文本
+	md := element.MarshalMarkdown([]element.Element{element.Link("https://x.com/a>b")})
+	if md != `[https://x.com/a\>b](https://x.com/a>b)` {
+		t.Fatalf("invalid autolink mismatch: %q", md)
+	}
+	if got := element.Marshal(element.ParseMarkdown(md)...); got != `<a href="https://x.com/a&gt;b">https://x.com/a&gt;b</a>` {
+		t.Fatalf("invalid autolink parse mismatch: %s", got)
+	}
+	// 文本中的空行无法还原，会被解析为分段
+	if got := element.Marshal(element.ParseMarkdown(element.MarshalMarkdown([]element.Element{element.Plain("a\n\nb")}))...); got != "<p>a</p><p>b</p>" {
+		t.Fatalf("blank line parse mismatch: %s", got)
+	}
+}
+
+func newElement[T element.Element](t *testing.T, children ...element.Element) T {
+	t.Helper()
+	e, err := element.New[T](nil)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	e.AddChild(children...)
+	return e
+}
+
+func TestRenderHTML(t *testing.T) {
+	elements, err := element.Parse(`<p>hi <at id="1" name="&lt;neo&gt;"/> <at type="all"/> <sharp id="c1" name="general"/></p>` +
+		`<quote id="q1">old</quote><spl>secret</spl><a href="javascript:alert(1)">bad</a><a href="https://example.com?a=1&amp;b=2">good</a>` +
+		`<img src="javascript:alert(1)"/><img src="https://example.com/a.png" title="a&quot;b"/><audio src="https://example.com/a.mp3"/>`)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	want := `<p>hi <span class="mention mention-user" data-id="1">@&lt;neo&gt;</span> ` +
+		`<span class="mention mention-all">@all</span> ` +
+		`<span class="mention mention-channel" data-id="c1">#general</span></p>` +
+		`<blockquote data-id="q1">old</blockquote>` +
+		`<details class="spoiler"><summary>spoiler</summary>secret</details>` +
+		`bad<a href="https://example.com?a=1&amp;b=2" rel="noopener noreferrer nofollow" target="_blank">good</a>` +
+		`<img src="https://example.com/a.png" alt="a&#34;b">` +
+		`<audio controls="controls" src="https://example.com/a.mp3"></audio>`
+	if got := element.RenderHTML(elements); got != want {
+		t.Fatalf("RenderHTML mismatch:\n got: %s\nwant: %s", got, want)
+	}
+
+	got := element.RenderHTML([]element.Element{element.AtUser("1"), element.Bold(element.Plain("x"))},
+		element.WithClassHook(func(e element.Element) string {
+			if e.Tag() == "b" {
+				return "strong"
+			}
+			return "chat"
+		}),
+		element.WithAllowedSchemes("https"),
+	)
+	if want := `<span class="mention mention-user chat" data-id="1">@1</span><b class="strong">x</b>`; got != want {
+		t.Fatalf("RenderHTML with options mismatch:\n got: %s\nwant: %s", got, want)
+	}
+
+	// 允许的协议不区分大小写
+	got = element.RenderHTML([]element.Element{element.Link("https://example.com", element.Plain("x"))}, element.WithAllowedSchemes("HTTPS"))
+	if want := `<a href="https://example.com" rel="noopener noreferrer nofollow" target="_blank">x</a>`; got != want {
+		t.Fatalf("RenderHTML scheme case mismatch:\n got: %s\nwant: %s", got, want)
+	}
+}
+
+func TestDowngrade(t *testing.T) {
+	source := `<p><at type="all"/> hi <b>bold <i>it</i></b></p>` +
+		`<button type="link" href="https://example.com">open</button><button id="b1" type="action">ok</button>` +
+		`<audio src="https://example.com/a.mp3"/><file src="https://example.com/f.zip" title="f.zip"/>`
+
+	elements, err := element.Parse(source)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	original := element.Marshal(elements...)
+	got := element.Marshal(element.Downgrade(elements, nil)...)
+	want := `<p> hi bold it</p><a href="https://example.com">open</a>ok` +
+		`<a href="https://example.com/a.mp3"/><a href="https://example.com/f.zip">f.zip</a>`
+	if got != want {
+		t.Fatalf("Downgrade mismatch:\n got: %s\nwant: %s", got, want)
+	}
+
+	// 同一组元素按不同的平台特性降级，降级不会修改传入的元素
+	got = element.Marshal(element.Downgrade(elements, []string{
+		element.FeatureAtAll, element.FeatureStrong, element.FeatureButton, element.FeatureAudio,
+	})...)
+	want = `<p><at type="all"/> hi <b>bold it</b></p>` +
+		`<button href="https://example.com" type="link">open</button><button id="b1" type="action">ok</button>` +
+		`<audio src="https://example.com/a.mp3"/><a href="https://example.com/f.zip">f.zip</a>`
+	if got != want {
+		t.Fatalf("Downgrade with features mismatch:\n got: %s\nwant: %s", got, want)
+	}
+	if got := element.Marshal(elements...); got != original {
+		t.Fatalf("Downgrade modified source:\n got: %s\nwant: %s", got, original)
+	}
+}
+
+func TestSplit(t *testing.T) {
+	marshalChunks := func(chunks [][]element.Element) []string {
+		result := make([]string, 0, len(chunks))
+		for _, chunk := range chunks {
+			result = append(result, element.Marshal(chunk...))
+		}
+		return result
+	}
+	check := func(t *testing.T, got, want []string) {
+		t.Helper()
+		if len(got) != len(want) {
+			t.Fatalf("chunks mismatch:\n got: %q\nwant: %q", got, want)
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Fatalf("chunk %d mismatch:\n got: %s\nwant: %s", i, got[i], want[i])
+			}
+		}
+	}
+
+	t.Run("resources", func(t *testing.T) {
+		elements, err := element.Parse(`<quote id="q"/>look<img src="a.png"/>and listen<audio src="a.mp3"/>end`)
+		if err != nil {
+			t.Fatalf("Parse failed: %v", err)
+		}
+		check(t, marshalChunks(element.Split(elements, element.WithSeparateResources("audio"))), []string{
+			`<quote id="q"/>look<img src="a.png"/>and listen`,
+			`<audio src="a.mp3"/>`,
+			`end`,
+		})
+		check(t, marshalChunks(element.Split(elements, element.WithSeparateResources())), []string{
+			`<quote id="q"/>look`,
+			`<img src="a.png"/>`,
+			`and listen`,
+			`<audio src="a.mp3"/>`,
+			`end`,
+		})
+	})
+
+	t.Run("length", func(t *testing.T) {
+		elements, err := element.Parse(`<quote id="q"/>hello world <at id="1" name="someone"/><b>bold text</b> tail text here`)
+		if err != nil {
+			t.Fatalf("Parse failed: %v", err)
+		}
+		check(t, marshalChunks(element.Split(elements, element.WithMaxLength(12))), []string{
+			`<quote id="q"/>hello world `,
+			`<at id="1" name="someone"/>`,
+			`<b>bold text</b> `,
+			`tail text `,
+			`here`,
+		})
+	})
+
+	t.Run("resource length", func(t *testing.T) {
+		elements, err := element.Parse(`hi<img src="a.png"/>yo`)
+		if err != nil {
+			t.Fatalf("Parse failed: %v", err)
+		}
+		check(t, marshalChunks(element.Split(elements, element.WithMaxLength(5))), []string{
+			`hi<img src="a.png"/>yo`,
+		})
+	})
+
+	t.Run("paragraph", func(t *testing.T) {
+		elements, err := element.Parse(`<p>aaaa bbbb cccc</p><p>dd</p>`)
+		if err != nil {
+			t.Fatalf("Parse failed: %v", err)
+		}
+		check(t, marshalChunks(element.Split(elements, element.WithMaxLength(10))), []string{
+			`<p>aaaa bbbb </p>`,
+			`<p>cccc</p><p>dd</p>`,
+		})
+	})
+
+	t.Run("oversized", func(t *testing.T) {
+		elements := []element.Element{element.Bold(element.Plain("abcdefghij")), element.Plain("hello world")}
+		check(t, marshalChunks(element.Split(elements, element.WithMaxLength(6))), []string{
+			`<b>abcdefghij</b>`,
+			`hello `,
+			`world`,
+		})
+	})
+
+	t.Run("nested resources", func(t *testing.T) {
+		elements, err := element.Parse(`<quote id="q"/><message><img src="a.png"/>hi<audio src="a.mp3"/></message>end`)
+		if err != nil {
+			t.Fatalf("Parse failed: %v", err)
+		}
+		check(t, marshalChunks(element.Split(elements, element.WithSeparateResources())), []string{
+			`<quote id="q"/><img src="a.png"/>`,
+			`<message>hi</message>`,
+			`<audio src="a.mp3"/>`,
+			`end`,
+		})
+	})
+
+	t.Run("quote", func(t *testing.T) {
+		elements, err := element.Parse(`<quote id="q"/><img src="a.png"/>text`)
+		if err != nil {
+			t.Fatalf("Parse failed: %v", err)
+		}
+		check(t, marshalChunks(element.Split(elements, element.WithSeparateResources())), []string{
+			`<quote id="q"/><img src="a.png"/>`,
+			`text`,
+		})
+
+		// 只有资源元素时引用与第一个资源元素一起发送，不会单独成为一条消息
+		elements, err = element.Parse(`<quote id="q"/><img src="a.png"/><audio src="a.mp3"/>`)
+		if err != nil {
+			t.Fatalf("Parse failed: %v", err)
+		}
+		check(t, marshalChunks(element.Split(elements, element.WithSeparateResources())), []string{
+			`<quote id="q"/><img src="a.png"/>`,
+			`<audio src="a.mp3"/>`,
+		})
+		if got := element.Split([]element.Element{newElement[*element.Quote](t)}); len(got) != 0 {
+			t.Fatalf("quote without content should not be sent: %q", marshalChunks(got))
+		}
+	})
+}
+
+func TestQuery(t *testing.T) {
+	elements, err := element.Parse(`<quote id="q"><at id="1"/><p><at id="2"/></p></quote>` +
+		`<at id="3"/><b>x</b><i>y</i><sharp id="c"/><strong>z<em>w</em></strong>`)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	cases := map[string]string{
+		"at":          `<at id="1"/><at id="2"/><at id="3"/>`,
+		"quote > at":  `<at id="1"/>`,
+		"quote at":    `<at id="1"/><at id="2"/>`,
+		"b, i":        `<b>x</b><i>y</i><b>z<i>w</i></b><i>w</i>`,
+		"quote + at":  `<at id="3"/>`,
+		"quote ~ b":   `<b>x</b><b>z<i>w</i></b>`,
+		"b + i":       `<i>y</i>`,
+		"strong > em": `<i>w</i>`,
+		"p > *":       `<at id="2"/>`,
+	}
+	for query, want := range cases {
+		if got := element.Marshal(element.Query(elements, query)...); got != want {
+			t.Errorf("Query(%q) mismatch:\n got: %s\nwant: %s", query, got, want)
+		}
+	}
+
+	// 兄弟组合符忽略元素之间的文本
+	spaced, err := element.Parse(`<at id="1"/> <at id="2"/> and <sharp id="c"/>`)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if got := element.Marshal(element.Query(spaced, "at + at")...); got != `<at id="2"/>` {
+		t.Errorf("adjacent query across text mismatch: %s", got)
+	}
+	if got := element.Marshal(element.Query(spaced, "at ~ sharp")...); got != `<sharp id="c"/>` {
+		t.Errorf("sibling query across text mismatch: %s", got)
+	}
+	// * 与兄弟组合符不匹配文本
+	if got := element.Marshal(element.Query(spaced, "at + *")...); got != `<at id="2"/><sharp id="c"/>` {
+		t.Errorf("universal adjacent query mismatch: %s", got)
+	}
+	if got := element.Marshal(element.Query(spaced, "at ~ *")...); got != `<at id="2"/><sharp id="c"/>` {
+		t.Errorf("universal sibling query mismatch: %s", got)
+	}
+	if got := element.Marshal(element.Query(spaced, "*")...); got != `<at id="1"/><at id="2"/><sharp id="c"/>` {
+		t.Errorf("universal query mismatch: %s", got)
+	}
+
+	for _, query := range []string{"", "quote >", "> at", "quote > > at", "at,", "b,, i"} {
+		if got := element.Query(elements, query); got != nil {
+			t.Errorf("malformed query %q should match nothing, got %s", query, element.Marshal(got...))
+		}
+	}
+}
+
+func TestWalkAndReplace(t *testing.T) {
+	elements, err := element.Parse(`<p>hi <at type="all"/><b>bad</b></p><quote id="q"><at id="1"/></quote>`)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	var pre, post []string
+	err = element.Walk(elements, func(e element.Element) error {
+		pre = append(pre, e.Tag())
+		if e.Tag() == "quote" {
+			return element.SkipChildren
+		}
+		return nil
+	}, func(e element.Element) error {
+		post = append(post, e.Tag())
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Walk failed: %v", err)
+	}
+	if got := strings.Join(pre, ","); got != "p,text,at,b,text,quote" {
+		t.Fatalf("pre order mismatch: %s", got)
+	}
+	if got := strings.Join(post, ","); got != "text,at,text,b,p,quote" {
+		t.Fatalf("post order mismatch: %s", got)
+	}
+
+	original := element.Marshal(elements...)
+	replaced := element.Replace(elements, "p at", func(e element.Element) []element.Element {
+		return []element.Element{element.Plain("@everyone")}
+	})
+	if got := element.Marshal(elements...); got != original {
+		t.Fatalf("Replace modified input:\n got: %s\nwant: %s", got, original)
+	}
+	// 匹配的容器元素收到子元素已经改写完成的副本
+	var container string
+	element.Replace(elements, "p", func(e element.Element) []element.Element {
+		container = element.Marshal(e)
+		return nil
+	})
+	if want := `<p>hi <at type="all"/><b>bad</b></p>`; container != want {
+		t.Fatalf("Replace container mismatch:\n got: %s\nwant: %s", container, want)
+	}
+	mapped := element.Map(replaced, func(e element.Element) element.Element {
+		if text, ok := e.(*element.Text); ok && text.Text == "bad" {
+			return element.Plain("***")
+		}
+		if _, ok := e.(*element.Quote); ok {
+			return nil
+		}
+		return e
+	})
+	if got, want := element.Marshal(mapped...), `<p>hi @everyone<b>***</b></p>`; got != want {
+		t.Fatalf("rewrite mismatch:\n got: %s\nwant: %s", got, want)
+	}
+	if got, want := element.Marshal(replaced...), `<p>hi @everyone<b>bad</b></p><quote id="q"><at id="1"/></quote>`; got != want {
+		t.Fatalf("Map modified input:\n got: %s\nwant: %s", got, want)
+	}
+}
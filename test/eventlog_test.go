@@ -0,0 +1,336 @@
+package testsuite
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/satori-protocol-go/satori-go/pkg/satori/client"
+	"github.com/satori-protocol-go/satori-go/pkg/satori/model/event"
+	"github.com/satori-protocol-go/satori-go/pkg/satori/model/operation"
+	"github.com/satori-protocol-go/satori-go/pkg/satori/server"
+	"golang.org/x/net/websocket"
+)
+
+func eventSns(events []*event.Event) []int64 {
+	sns := make([]int64, 0, len(events))
+	for _, e := range events {
+		sns = append(sns, e.Sn)
+	}
+	return sns
+}
+
+func checkSince(t *testing.T, log server.EventLog, sn int64, want []int64, resync bool) {
+	t.Helper()
+	events, err := log.Since(sn)
+	var errResync *server.ErrResync
+	if resync != errors.As(err, &errResync) {
+		t.Fatalf("Since(%d) error mismatch: %v", sn, err)
+	}
+	got := eventSns(events)
+	if len(got) != len(want) {
+		t.Fatalf("Since(%d) = %v, want %v", sn, got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("Since(%d) = %v, want %v", sn, got, want)
+		}
+	}
+}
+
+func TestMemoryLog(t *testing.T) {
+	log := server.NewMemoryLog(3)
+	for range 5 {
+		if err := log.Append(&event.Event{Type: event.EventTypeMessageCreated}); err != nil {
+			t.Fatalf("Append failed: %v", err)
+		}
+	}
+	checkSince(t, log, 0, []int64{3, 4, 5}, false)
+	checkSince(t, log, 3, []int64{4, 5}, false)
+	checkSince(t, log, 5, nil, false)
+	checkSince(t, log, 1, []int64{3, 4, 5}, true)
+	checkSince(t, log, 9, nil, true)
+}
+
+func TestFileLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	log, err := server.OpenFileLog(path, 2)
+	if err != nil {
+		t.Fatalf("OpenFileLog failed: %v", err)
+	}
+	// 超过容量两倍时触发压缩
+	for range 6 {
+		if err := log.Append(&event.Event{Type: event.EventTypeMessageCreated}); err != nil {
+			t.Fatalf("Append failed: %v", err)
+		}
+	}
+	if err := log.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	log, err = server.OpenFileLog(path, 2)
+	if err != nil {
+		t.Fatalf("reopen failed: %v", err)
+	}
+	defer log.Close()
+	checkSince(t, log, 4, []int64{5, 6}, false)
+	e := &event.Event{Type: event.EventTypeMessageCreated}
+	if err := log.Append(e); err != nil {
+		t.Fatalf("Append failed: %v", err)
+	}
+	if e.Sn != 7 {
+		t.Fatalf("sn should continue after reopen, got %d", e.Sn)
+	}
+	checkSince(t, log, 4, []int64{6, 7}, true)
+}
+
+func TestFileLogCompactError(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "events")
+	if err := os.Mkdir(dir, 0o755); err != nil {
+		t.Fatalf("Mkdir failed: %v", err)
+	}
+	log, err := server.OpenFileLog(filepath.Join(dir, "events.jsonl"), 1)
+	if err != nil {
+		t.Fatalf("OpenFileLog failed: %v", err)
+	}
+	defer log.Close()
+	// 目录被删除后无法创建压缩用的临时文件，但已打开的文件仍可写入
+	if err := os.RemoveAll(dir); err != nil {
+		t.Fatalf("RemoveAll failed: %v", err)
+	}
+	for range 4 {
+		if err := log.Append(&event.Event{Type: event.EventTypeMessageCreated}); err != nil {
+			t.Fatalf("Append should not fail on compaction error: %v", err)
+		}
+	}
+	if log.Err() == nil {
+		t.Fatalf("compaction error should be recorded")
+	}
+	checkSince(t, log, 3, []int64{4}, false)
+}
+
+func TestFileLogPartialLine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	log, err := server.OpenFileLog(path, 10)
+	if err != nil {
+		t.Fatalf("OpenFileLog failed: %v", err)
+	}
+	if err := log.Append(&event.Event{Type: event.EventTypeMessageCreated}); err != nil {
+		t.Fatalf("Append failed: %v", err)
+	}
+	log.Close()
+
+	// 模拟写入到一半时进程中断
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		t.Fatalf("open failed: %v", err)
+	}
+	if _, err := file.WriteString(`{"sn":2,"type":"mess`); err != nil {
+		t.Fatalf("write failed: %v", err)
+	}
+	file.Close()
+
+	log, err = server.OpenFileLog(path, 10)
+	if err != nil {
+		t.Fatalf("reopen failed: %v", err)
+	}
+	for range 2 {
+		if err := log.Append(&event.Event{Type: event.EventTypeMessageCreated}); err != nil {
+			t.Fatalf("Append failed: %v", err)
+		}
+	}
+	log.Close()
+
+	log, err = server.OpenFileLog(path, 10)
+	if err != nil {
+		t.Fatalf("reopen failed: %v", err)
+	}
+	defer log.Close()
+	checkSince(t, log, 0, []int64{1, 2, 3}, false)
+}
+
+func TestServerReplay(t *testing.T) {
+	s := server.New(echoAdapter{}, server.WithEventLog(server.NewMemoryLog(10)))
+	for range 3 {
+		if err := s.Dispatch(&event.Event{Type: event.EventTypeMessageCreated}); err != nil {
+			t.Fatalf("Dispatch failed: %v", err)
+		}
+	}
+	srv := httptest.NewServer(s)
+	defer srv.Close()
+
+	conn, err := websocket.Dial("ws"+srv.URL[len("http"):]+"/v1/events", "", srv.URL)
+	if err != nil {
+		t.Fatalf("dial failed: %v", err)
+	}
+	defer conn.Close()
+	if err := websocket.JSON.Send(conn, &operation.Operation{Op: operation.OpcodeIdentify, Body: &operation.IdentifyBody{Sn: 1}}); err != nil {
+		t.Fatalf("send identify failed: %v", err)
+	}
+	_ = conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	var ops []operation.Operation
+	for range 3 {
+		var op operation.Operation
+		if err := websocket.JSON.Receive(conn, &op); err != nil {
+			t.Fatalf("receive failed: %v", err)
+		}
+		ops = append(ops, op)
+	}
+	if ops[0].Op != operation.OpcodeReady {
+		t.Fatalf("first operation should be READY, got %d", ops[0].Op)
+	}
+	for i, want := range []int64{2, 3} {
+		body, ok := ops[i+1].Body.(*operation.EventBody)
+		if !ok || body.Sn != want {
+			t.Fatalf("replayed event %d mismatch: %+v", i, ops[i+1])
+		}
+	}
+}
+
+func TestWebSocketResync(t *testing.T) {
+	resynced := make(chan *server.ErrResync, 1)
+	s := server.New(echoAdapter{},
+		server.WithEventLog(server.NewMemoryLog(1)),
+		server.WithResyncHandler(func(ctx context.Context, err *server.ErrResync) { resynced <- err }),
+	)
+	for range 3 {
+		if err := s.Dispatch(&event.Event{Type: event.EventTypeMessageCreated}); err != nil {
+			t.Fatalf("Dispatch failed: %v", err)
+		}
+	}
+	srv := httptest.NewServer(s)
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	resync := make(chan struct{}, 1)
+	events := make(chan *event.Event, 1)
+	ws := client.NewWebSocket(srv.URL, "", client.HandlerFunc(func(ctx context.Context, e *event.Event) {
+		events <- e
+	}),
+		client.WithSn(1),
+		client.WithResyncHandler(func(ctx context.Context) { resync <- struct{}{} }),
+	)
+	go func() { _ = ws.Run(ctx) }()
+
+	select {
+	case <-resync:
+	case <-ctx.Done():
+		t.Fatalf("timed out waiting for resync")
+	}
+	select {
+	case e := <-events:
+		if e.Sn != 3 {
+			t.Fatalf("replayed event mismatch: %+v", e)
+		}
+	case <-ctx.Done():
+		t.Fatalf("timed out waiting for EVENT")
+	}
+	select {
+	case err := <-resynced:
+		if err.Sn != 1 || err.Oldest != 3 {
+			t.Fatalf("server resync mismatch: %+v", err)
+		}
+	case <-ctx.Done():
+		t.Fatalf("timed out waiting for server resync")
+	}
+}
+
+func TestWebSocketResumeAfterRestart(t *testing.T) {
+	// 重启后的服务端序列号从 1 开始，客户端仍持有重启前的序列号
+	s := server.New(echoAdapter{}, server.WithEventLog(server.NewMemoryLog(10)))
+	srv := httptest.NewServer(s)
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	ready := make(chan struct{}, 1)
+	resync := make(chan struct{}, 1)
+	events := make(chan *event.Event, 3)
+	ws := client.NewWebSocket(srv.URL, "", client.HandlerFunc(func(ctx context.Context, e *event.Event) {
+		events <- e
+	}),
+		client.WithSn(50),
+		client.WithReadyHandler(func(ctx context.Context, body *operation.ReadyBody) { ready <- struct{}{} }),
+		client.WithResyncHandler(func(ctx context.Context) { resync <- struct{}{} }),
+	)
+	go func() { _ = ws.Run(ctx) }()
+	select {
+	case <-ready:
+	case <-ctx.Done():
+		t.Fatalf("timed out waiting for READY")
+	}
+
+	for range 3 {
+		if err := s.Dispatch(&event.Event{Type: event.EventTypeMessageCreated}); err != nil {
+			t.Fatalf("Dispatch failed: %v", err)
+		}
+	}
+	for want := int64(1); want <= 3; want++ {
+		select {
+		case e := <-events:
+			if e.Sn != want {
+				t.Fatalf("event mismatch: want sn %d, got %d", want, e.Sn)
+			}
+		case <-ctx.Done():
+			t.Fatalf("timed out waiting for event %d", want)
+		}
+	}
+	select {
+	case <-resync:
+	default:
+		t.Fatalf("expected resync after restart")
+	}
+	if ws.Sn() != 3 {
+		t.Fatalf("client sn should follow the restarted server, got %d", ws.Sn())
+	}
+}
+
+func TestServerDispatchOrder(t *testing.T) {
+	s := server.New(echoAdapter{}, server.WithEventLog(server.NewMemoryLog(0)))
+	srv := httptest.NewServer(s)
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	ready := make(chan struct{}, 1)
+	events := make(chan *event.Event, 256)
+	ws := client.NewWebSocket(srv.URL, "", client.HandlerFunc(func(ctx context.Context, e *event.Event) {
+		events <- e
+	}), client.WithReadyHandler(func(ctx context.Context, body *operation.ReadyBody) { ready <- struct{}{} }))
+	go func() { _ = ws.Run(ctx) }()
+	select {
+	case <-ready:
+	case <-ctx.Done():
+		t.Fatalf("timed out waiting for READY")
+	}
+
+	// 并发推送的事件也应按序列号顺序送达
+	var wg sync.WaitGroup
+	for range 100 {
+		wg.Go(func() {
+			if err := s.Dispatch(&event.Event{Type: event.EventTypeMessageCreated}); err != nil {
+				t.Errorf("Dispatch failed: %v", err)
+			}
+		})
+	}
+	wg.Wait()
+	for want := int64(1); want <= 100; want++ {
+		select {
+		case e := <-events:
+			if e.Sn != want {
+				t.Fatalf("event out of order: want sn %d, got %d", want, e.Sn)
+			}
+		case <-ctx.Done():
+			t.Fatalf("timed out waiting for event %d", want)
+		}
+	}
+	if ws.Sn() != 100 {
+		t.Fatalf("client sn mismatch: %d", ws.Sn())
+	}
+}
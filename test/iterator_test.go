@@ -0,0 +1,116 @@
+package testsuite
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/satori-protocol-go/satori-go/pkg/satori/model/define"
+)
+
+func pagedFetcher(pages map[string]*define.Paginated[int]) define.PageFetcher[int] {
+	return func(ctx context.Context, next string) (*define.Paginated[int], error) {
+		page, ok := pages[next]
+		if !ok {
+			return nil, errors.New("unknown token " + next)
+		}
+		return page, nil
+	}
+}
+
+func TestPaginatedAll(t *testing.T) {
+	fetch := pagedFetcher(map[string]*define.Paginated[int]{
+		"":   {Data: []int{1, 2}, Next: "p2"},
+		"p2": {Data: []int{3, 4}, Next: "p3"},
+		"p3": {Data: []int{5}},
+	})
+
+	var got []int
+	for item, err := range define.All(context.Background(), 0, fetch) {
+		if err != nil {
+			t.Fatalf("All failed: %v", err)
+		}
+		got = append(got, item)
+	}
+	if !reflect.DeepEqual(got, []int{1, 2, 3, 4, 5}) {
+		t.Fatalf("All mismatch: %v", got)
+	}
+
+	got = got[:0]
+	for item, err := range define.All(context.Background(), 3, fetch) {
+		if err != nil {
+			t.Fatalf("All with limit failed: %v", err)
+		}
+		got = append(got, item)
+	}
+	if !reflect.DeepEqual(got, []int{1, 2, 3}) {
+		t.Fatalf("All limit mismatch: %v", got)
+	}
+
+	// limit 恰好落在页边界时不应再拉取下一页
+	fetched := 0
+	counted := func(ctx context.Context, next string) (*define.Paginated[int], error) {
+		fetched++
+		return fetch(ctx, next)
+	}
+	got = got[:0]
+	for item, err := range define.All(context.Background(), 2, counted) {
+		if err != nil {
+			t.Fatalf("All with page limit failed: %v", err)
+		}
+		got = append(got, item)
+	}
+	if !reflect.DeepEqual(got, []int{1, 2}) || fetched != 1 {
+		t.Fatalf("All page limit mismatch: %v, fetched %d pages", got, fetched)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var last error
+	for item, err := range define.All(ctx, 0, fetch) {
+		if err != nil {
+			last = err
+			break
+		}
+		if item == 2 {
+			cancel()
+		}
+	}
+	if !errors.Is(last, context.Canceled) {
+		t.Fatalf("All should stop with context.Canceled, got: %v", last)
+	}
+}
+
+func TestBidiPaginatedBackward(t *testing.T) {
+	pages := map[string]*define.BidiPaginated[int]{
+		"":   {Data: []int{4, 5, 6}, Prev: "p1", Next: "n1"},
+		"p1": {Data: []int{1, 2, 3}, Next: ""},
+		"n1": {Data: []int{7}},
+	}
+	fetch := func(ctx context.Context, token string) (*define.BidiPaginated[int], error) {
+		return pages[token], nil
+	}
+
+	var backward []int
+	for item, err := range define.Backward(context.Background(), 0, fetch) {
+		if err != nil {
+			t.Fatalf("Backward failed: %v", err)
+		}
+		backward = append(backward, item)
+	}
+	if !reflect.DeepEqual(backward, []int{6, 5, 4, 3, 2, 1}) {
+		t.Fatalf("Backward mismatch: %v", backward)
+	}
+
+	var forward []int
+	for item, err := range define.Forward(context.Background(), 0, fetch) {
+		if err != nil {
+			t.Fatalf("Forward failed: %v", err)
+		}
+		forward = append(forward, item)
+	}
+	if !reflect.DeepEqual(forward, []int{4, 5, 6, 7}) {
+		t.Fatalf("Forward mismatch: %v", forward)
+	}
+}
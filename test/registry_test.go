@@ -0,0 +1,121 @@
+package testsuite
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/satori-protocol-go/satori-go/pkg/satori/client"
+	"github.com/satori-protocol-go/satori-go/pkg/satori/model/event"
+	"github.com/satori-protocol-go/satori-go/pkg/satori/model/login"
+	"github.com/satori-protocol-go/satori-go/pkg/satori/model/user"
+)
+
+func TestLoginRegistry(t *testing.T) {
+	ctx := context.Background()
+	registry := client.NewLoginRegistry()
+	type transition struct {
+		sn       int64
+		from, to login.LoginStatus
+	}
+	var transitions []transition
+	registry.OnStatusChange(func(ctx context.Context, l *login.Login, from, to login.LoginStatus) {
+		transitions = append(transitions, transition{l.Sn, from, to})
+	})
+
+	registry.Reset(ctx, []*login.Login{
+		{Sn: 1, Platform: "qq", User: &user.User{Id: "10"}, Status: login.LoginStatusOnline},
+		{Sn: 2, Platform: "discord", User: &user.User{Id: "20"}, Status: login.LoginStatusOffline},
+	})
+	if l, ok := registry.Get("qq", "10"); !ok || l.Sn != 1 {
+		t.Fatalf("Get mismatch: %+v", l)
+	}
+	if got := registry.ByPlatform("discord"); len(got) != 1 || got[0].Sn != 2 {
+		t.Fatalf("ByPlatform mismatch: %+v", got)
+	}
+
+	registry.HandleEvent(ctx, &event.Event{
+		Type:  event.EventTypeLoginUpdated,
+		Login: &login.Login{Sn: 1, Status: login.LoginStatusReconnect},
+	})
+	if l, ok := registry.GetBySn(1); !ok || l.Status != login.LoginStatusReconnect || l.Platform != "qq" || l.User.Id != "10" {
+		t.Fatalf("partial update should keep known fields: %+v", l)
+	}
+
+	registry.HandleEvent(ctx, &event.Event{
+		Type:  event.EventTypeLoginAdded,
+		Login: &login.Login{Sn: 3, Platform: "qq", User: &user.User{Id: "30"}, Status: login.LoginStatusOnline},
+	})
+	registry.HandleEvent(ctx, &event.Event{
+		Type:  event.EventTypeLoginRemoved,
+		Login: &login.Login{Sn: 1},
+	})
+	if _, ok := registry.Get("qq", "10"); ok {
+		t.Fatalf("removed login should not be found")
+	}
+	if got := registry.List(); len(got) != 2 || got[0].Sn != 2 || got[1].Sn != 3 {
+		t.Fatalf("List mismatch: %+v", got)
+	}
+
+	want := []transition{
+		{1, login.LoginStatusOffline, login.LoginStatusOnline},
+		{1, login.LoginStatusOnline, login.LoginStatusReconnect},
+		{3, login.LoginStatusOffline, login.LoginStatusOnline},
+		{1, login.LoginStatusReconnect, login.LoginStatusOffline},
+	}
+	if len(transitions) != len(want) {
+		t.Fatalf("transitions mismatch: %+v", transitions)
+	}
+	for i := range want {
+		if transitions[i] != want[i] {
+			t.Fatalf("transition %d mismatch: got %+v, want %+v", i, transitions[i], want[i])
+		}
+	}
+}
+
+func TestLoginRegistryMergesBySnAndAccount(t *testing.T) {
+	ctx := context.Background()
+	registry := client.NewLoginRegistry()
+	type transition struct {
+		sn       int64
+		from, to login.LoginStatus
+	}
+	var transitions []transition
+	registry.OnStatusChange(func(ctx context.Context, l *login.Login, from, to login.LoginStatus) {
+		transitions = append(transitions, transition{l.Sn, from, to})
+	})
+
+	registry.Reset(ctx, []*login.Login{
+		{Sn: 1, Platform: "qq", User: &user.User{Id: "10"}, Status: login.LoginStatusOnline},
+		{Platform: "discord", User: &user.User{Id: "20"}, Status: login.LoginStatusOnline},
+	})
+	// 没有序列号的更新按平台名称与平台账号合并到已有的登录
+	registry.HandleEvent(ctx, &event.Event{
+		Type:  event.EventTypeLoginUpdated,
+		Login: &login.Login{Platform: "qq", User: &user.User{Id: "10"}, Status: login.LoginStatusReconnect},
+	})
+	// 带有序列号的更新合并到没有序列号的登录
+	registry.HandleEvent(ctx, &event.Event{
+		Type:  event.EventTypeLoginUpdated,
+		Login: &login.Login{Sn: 2, Platform: "discord", User: &user.User{Id: "20"}, Status: login.LoginStatusOffline},
+	})
+
+	if got := registry.List(); len(got) != 2 || got[0].Sn != 1 || got[1].Sn != 2 {
+		t.Fatalf("List mismatch: %+v", got)
+	}
+	if l, ok := registry.Get("qq", "10"); !ok || l.Sn != 1 || l.Status != login.LoginStatusReconnect {
+		t.Fatalf("Get mismatch: %+v", l)
+	}
+	if l, ok := registry.GetBySn(2); !ok || l.Status != login.LoginStatusOffline {
+		t.Fatalf("GetBySn mismatch: %+v", l)
+	}
+	want := []transition{
+		{1, login.LoginStatusOffline, login.LoginStatusOnline},
+		{0, login.LoginStatusOffline, login.LoginStatusOnline},
+		{1, login.LoginStatusOnline, login.LoginStatusReconnect},
+		{2, login.LoginStatusOnline, login.LoginStatusOffline},
+	}
+	if !slices.Equal(transitions, want) {
+		t.Fatalf("transitions mismatch:\n got: %+v\nwant: %+v", transitions, want)
+	}
+}
@@ -0,0 +1,138 @@
+package testsuite
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/satori-protocol-go/satori-go/pkg/satori/client"
+	"github.com/satori-protocol-go/satori-go/pkg/satori/model/event"
+	"github.com/satori-protocol-go/satori-go/pkg/satori/model/guild"
+	"github.com/satori-protocol-go/satori-go/pkg/satori/model/guildmember"
+	"github.com/satori-protocol-go/satori-go/pkg/satori/model/interaction"
+	"github.com/satori-protocol-go/satori-go/pkg/satori/model/message"
+	"github.com/satori-protocol-go/satori-go/pkg/satori/model/user"
+	"github.com/satori-protocol-go/satori-go/pkg/satori/router"
+)
+
+func TestRouterMatch(t *testing.T) {
+	cases := []struct {
+		pattern string
+		typ     event.EventType
+		want    bool
+	}{
+		{pattern: "*", typ: event.EventTypeMessageCreated, want: true},
+		{pattern: "message-created", typ: event.EventTypeMessageCreated, want: true},
+		{pattern: "message-created", typ: event.EventTypeMessageDeleted, want: false},
+		{pattern: "guild-member-*", typ: event.EventTypeGuildMemberAdded, want: true},
+		{pattern: "guild-member-*", typ: event.EventTypeGuildAdded, want: false},
+		{pattern: "interaction/*", typ: event.EventTypeInteractionButton, want: true},
+	}
+	for _, tc := range cases {
+		if got := router.Match(tc.pattern, tc.typ); got != tc.want {
+			t.Fatalf("Match(%q, %q) = %v, want %v", tc.pattern, tc.typ, got, tc.want)
+		}
+	}
+}
+
+func TestRouterDispatch(t *testing.T) {
+	r := router.New()
+	var calls []string
+
+	r.OnMessageCreated(func(ctx context.Context, e *event.Event, m *message.Message) {
+		calls = append(calls, "created:"+m.Content)
+	})
+	r.OnGuildMemberAdded(func(ctx context.Context, e *event.Event, g *guild.Guild, m *guildmember.GuildMember, u *user.User) {
+		calls = append(calls, "member:"+g.Id+":"+u.Id)
+	})
+	r.OnInteractionButton(func(ctx context.Context, e *event.Event, b *interaction.Button) {
+		calls = append(calls, "button:"+b.Id)
+	})
+	r.HandleFunc("guild-member-*", func(ctx context.Context, e *event.Event) {
+		calls = append(calls, "wildcard:"+string(e.Type))
+	})
+	r.HandleFunc("*", func(ctx context.Context, e *event.Event) {
+		calls = append(calls, "all")
+	})
+
+	ctx := context.Background()
+	r.HandleEvent(ctx, &event.Event{Type: event.EventTypeMessageCreated, Message: &message.Message{Content: "hi"}})
+	r.HandleEvent(ctx, &event.Event{Type: event.EventTypeGuildMemberAdded, Guild: &guild.Guild{Id: "g1"}, User: &user.User{Id: "u1"}})
+	r.HandleEvent(ctx, &event.Event{Type: event.EventTypeInteractionButton, Button: &interaction.Button{Id: "b1"}})
+	r.HandleEvent(ctx, &event.Event{Type: event.EventTypeGuildMemberRemoved})
+
+	want := []string{
+		"created:hi", "all",
+		"member:g1:u1", "wildcard:guild-member-added", "all",
+		"button:b1", "all",
+		"wildcard:guild-member-removed", "all",
+	}
+	if !reflect.DeepEqual(calls, want) {
+		t.Fatalf("dispatch mismatch:\n got=%v\nwant=%v", calls, want)
+	}
+}
+
+func TestRouterMiddleware(t *testing.T) {
+	r := router.New()
+	var calls []string
+
+	r.Use(func(ctx context.Context, e *event.Event, next router.Next) {
+		calls = append(calls, "log:before")
+		next(ctx)
+		calls = append(calls, "log:after")
+	})
+	r.Use(router.Recover(func(ctx context.Context, e *event.Event, recovered any) {
+		calls = append(calls, "recovered:"+recovered.(string))
+	}))
+	r.Use(router.Only(string(event.EventTypeMessageCreated), func(ctx context.Context, e *event.Event, next router.Next) {
+		if e.User != nil && e.User.IsBot {
+			calls = append(calls, "blocked")
+			return
+		}
+		next(ctx)
+	}))
+	r.OnMessageCreated(func(ctx context.Context, e *event.Event, m *message.Message) {
+		calls = append(calls, "handler:"+m.Content)
+	})
+
+	admin := r.Group(func(ctx context.Context, e *event.Event, next router.Next) {
+		if e.User == nil || e.User.Id != "admin" {
+			return
+		}
+		next(ctx)
+	})
+	admin.OnMessageCreated(func(ctx context.Context, e *event.Event, m *message.Message) {
+		calls = append(calls, "admin:"+m.Content)
+	})
+	admin.OnMessageDeleted(func(ctx context.Context, e *event.Event, m *message.Message) {
+		panic("boom")
+	})
+
+	ctx := context.Background()
+	r.HandleEvent(ctx, &event.Event{Type: event.EventTypeMessageCreated, User: &user.User{Id: "u1"}, Message: &message.Message{Content: "a"}})
+	r.HandleEvent(ctx, &event.Event{Type: event.EventTypeMessageCreated, User: &user.User{Id: "bot", IsBot: true}, Message: &message.Message{Content: "b"}})
+	r.HandleEvent(ctx, &event.Event{Type: event.EventTypeMessageCreated, User: &user.User{Id: "admin"}, Message: &message.Message{Content: "c"}})
+	r.HandleEvent(ctx, &event.Event{Type: event.EventTypeMessageDeleted, User: &user.User{Id: "admin"}, Message: &message.Message{}})
+
+	want := []string{
+		"log:before", "handler:a", "log:after",
+		"log:before", "blocked", "log:after",
+		"log:before", "handler:c", "admin:c", "log:after",
+		"log:before", "recovered:boom", "log:after",
+	}
+	if !reflect.DeepEqual(calls, want) {
+		t.Fatalf("middleware mismatch:\n got=%v\nwant=%v", calls, want)
+	}
+
+	calls = calls[:0]
+	wrapped := router.Wrap(client.HandlerFunc(func(ctx context.Context, e *event.Event) {
+		calls = append(calls, "wrapped")
+	}), func(ctx context.Context, e *event.Event, next router.Next) {
+		calls = append(calls, "mw")
+		next(ctx)
+	})
+	wrapped.HandleEvent(ctx, &event.Event{Type: event.EventTypeGuildAdded})
+	if !reflect.DeepEqual(calls, []string{"mw", "wrapped"}) {
+		t.Fatalf("Wrap mismatch: %v", calls)
+	}
+}
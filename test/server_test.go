@@ -0,0 +1,335 @@
+package testsuite
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/satori-protocol-go/satori-go/pkg/satori/client"
+	"github.com/satori-protocol-go/satori-go/pkg/satori/model/event"
+	"github.com/satori-protocol-go/satori-go/pkg/satori/model/login"
+	"github.com/satori-protocol-go/satori-go/pkg/satori/model/message"
+	"github.com/satori-protocol-go/satori-go/pkg/satori/model/message/element"
+	"github.com/satori-protocol-go/satori-go/pkg/satori/model/operation"
+	"github.com/satori-protocol-go/satori-go/pkg/satori/server"
+	"golang.org/x/net/websocket"
+)
+
+type echoAdapter struct {
+	server.UnimplementedAdapter
+}
+
+func (echoAdapter) Logins(ctx context.Context) ([]*login.Login, error) {
+	return []*login.Login{{Sn: 1, Platform: "echo", Status: login.LoginStatusOnline, Adapter: "echo"}}, nil
+}
+
+func (echoAdapter) MessageCreate(ctx context.Context, channelId, content string) ([]*message.Message, error) {
+	platform, userId := server.LoginFromContext(ctx)
+	if channelId == "" {
+		return nil, &server.ErrStatus{StatusCode: http.StatusBadRequest, Message: "missing channel_id"}
+	}
+	return []*message.Message{{Id: platform + "/" + userId, Content: content}}, nil
+}
+
+func TestServerAPI(t *testing.T) {
+	srv := httptest.NewServer(server.New(echoAdapter{}, server.WithToken("secret")))
+	defer srv.Close()
+	ctx := context.Background()
+
+	c := client.New(srv.URL, client.WithToken("secret")).WithLogin("echo", "42")
+	created, err := c.MessageCreate(ctx, "c1", "hello")
+	if err != nil {
+		t.Fatalf("MessageCreate failed: %v", err)
+	}
+	if len(created) != 1 || created[0].Id != "echo/42" || created[0].Content != "hello" {
+		t.Fatalf("MessageCreate result mismatch: %+v", created)
+	}
+
+	if _, err := c.MessageCreate(ctx, "", "hello"); !errors.Is(err, &client.ErrBadRequest{}) {
+		t.Fatalf("expected ErrBadRequest, got: %v", err)
+	}
+	if err := c.MessageDelete(ctx, "c1", "m1"); !errors.Is(err, &client.ErrMethodNotAllowed{}) {
+		t.Fatalf("expected ErrMethodNotAllowed, got: %v", err)
+	}
+	if err := c.Internal(ctx, "unknown", nil, nil); !errors.Is(err, &client.ErrNotFound{}) {
+		t.Fatalf("expected ErrNotFound, got: %v", err)
+	}
+	anonymous := client.New(srv.URL).WithLogin("echo", "42")
+	if _, err := anonymous.MessageCreate(ctx, "c1", "hello"); !errors.Is(err, &client.ErrUnauthorized{}) {
+		t.Fatalf("expected ErrUnauthorized, got: %v", err)
+	}
+}
+
+func TestServerEvents(t *testing.T) {
+	s := server.New(echoAdapter{}, server.WithToken("secret"))
+	srv := httptest.NewServer(s)
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	ready := make(chan *operation.ReadyBody, 1)
+	events := make(chan *event.Event, 1)
+	ws := client.NewWebSocket(srv.URL, "secret", client.HandlerFunc(func(ctx context.Context, e *event.Event) {
+		events <- e
+	}),
+		client.WithPingInterval(20*time.Millisecond),
+		client.WithReadyHandler(func(ctx context.Context, body *operation.ReadyBody) {
+			ready <- body
+		}),
+	)
+	go func() { _ = ws.Run(ctx) }()
+
+	select {
+	case body := <-ready:
+		if len(body.Logins) != 1 || body.Logins[0].Platform != "echo" {
+			t.Fatalf("ready body mismatch: %+v", body)
+		}
+	case <-ctx.Done():
+		t.Fatalf("timed out waiting for READY")
+	}
+
+	// 确认服务端会回复 PING
+	conn, err := websocket.Dial("ws"+srv.URL[len("http"):]+"/v1/events", "", srv.URL)
+	if err != nil {
+		t.Fatalf("dial failed: %v", err)
+	}
+	defer conn.Close()
+	if err := websocket.JSON.Send(conn, &operation.Operation{Op: operation.OpcodeIdentify, Body: &operation.IdentifyBody{Token: "secret"}}); err != nil {
+		t.Fatalf("send identify failed: %v", err)
+	}
+	_ = conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	var op operation.Operation
+	if err := websocket.JSON.Receive(conn, &op); err != nil || op.Op != operation.OpcodeReady {
+		t.Fatalf("expected READY, got op %d: %v", op.Op, err)
+	}
+	if err := websocket.JSON.Send(conn, &operation.Operation{Op: operation.OpcodePing}); err != nil {
+		t.Fatalf("send ping failed: %v", err)
+	}
+	if err := websocket.JSON.Receive(conn, &op); err != nil || op.Op != operation.OpcodePong {
+		t.Fatalf("expected PONG, got op %d: %v", op.Op, err)
+	}
+
+	if err := s.Dispatch(&event.Event{Type: event.EventTypeMessageCreated, Message: &message.Message{Id: "m1"}}); err != nil {
+		t.Fatalf("Dispatch failed: %v", err)
+	}
+	select {
+	case e := <-events:
+		if e.Type != event.EventTypeMessageCreated || e.Sn != 1 || e.Message.Id != "m1" {
+			t.Fatalf("event mismatch: %+v", e)
+		}
+	case <-ctx.Done():
+		t.Fatalf("timed out waiting for EVENT")
+	}
+}
+
+func TestServerEventsRejectsBadToken(t *testing.T) {
+	srv := httptest.NewServer(server.New(echoAdapter{}, server.WithToken("secret")))
+	defer srv.Close()
+
+	conn, err := websocket.Dial("ws"+srv.URL[len("http"):]+"/v1/events", "", srv.URL)
+	if err != nil {
+		t.Fatalf("dial failed: %v", err)
+	}
+	defer conn.Close()
+	if err := websocket.JSON.Send(conn, &operation.Operation{Op: operation.OpcodeIdentify, Body: &operation.IdentifyBody{Token: "nope"}}); err != nil {
+		t.Fatalf("send identify failed: %v", err)
+	}
+	_ = conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	var op operation.Operation
+	if err := websocket.JSON.Receive(conn, &op); err == nil {
+		t.Fatalf("server should close connection on bad token, got op %d", op.Op)
+	}
+}
+
+func TestServerProxy(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "" {
+			t.Errorf("authorization leaked to upstream: %q", r.Header.Get("Authorization"))
+		}
+		w.Write([]byte("image:" + r.URL.Path + "?" + r.URL.RawQuery))
+	}))
+	defer upstream.Close()
+
+	s := server.New(echoAdapter{}, server.WithToken("secret"))
+	s.AddProxy(upstream.URL+"/assets/", nil)
+	srv := httptest.NewServer(s)
+	defer srv.Close()
+
+	c := client.New(srv.URL, client.WithToken("secret"))
+	c.SetProxyUrls(s.ProxyUrls())
+	src := upstream.URL + "/assets/a.png?size=1"
+	proxied := c.ProxyURL(src)
+	if proxied != srv.URL+"/v1/proxy/"+src {
+		t.Fatalf("ProxyURL mismatch: %q", proxied)
+	}
+	if other := c.ProxyURL("https://example.com/a.png"); other != "https://example.com/a.png" {
+		t.Fatalf("unregistered url should not be proxied: %q", other)
+	}
+	// 客户端与服务端使用相同的匹配规则，不会改写服务端会拒绝的地址
+	strict := client.New(srv.URL)
+	strict.SetProxyUrls([]string{"https://a.com"})
+	for _, src := range []string{"https://a.com.evil/x", "https://a.com/../x", "https://user@a.com/x"} {
+		if got := strict.ProxyURL(src); got != src {
+			t.Fatalf("%q should not be proxied: %q", src, got)
+		}
+	}
+	if got := strict.ProxyURL("https://A.com/x"); got != srv.URL+"/v1/proxy/https://A.com/x" {
+		t.Fatalf("ProxyURL should match the host case-insensitively: %q", got)
+	}
+
+	resp, err := http.Get(proxied)
+	if err != nil {
+		t.Fatalf("proxy request failed: %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || string(body) != "image:/assets/a.png?size=1" {
+		t.Fatalf("proxy response mismatch: %d %q", resp.StatusCode, body)
+	}
+
+	resp, err = http.Get(srv.URL + "/v1/proxy/" + upstream.URL + "/private/a.png")
+	if err != nil {
+		t.Fatalf("proxy request failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusForbidden {
+		t.Fatalf("expected 403 for unregistered prefix, got %d", resp.StatusCode)
+	}
+
+	for _, path := range []string{
+		"/v1/proxy/" + upstream.URL + "/assets/../private/a.png",
+		"/v1/proxy/" + upstream.URL + "/assets/%2e%2e/private/a.png",
+		"/v1/proxy/" + upstream.URL + "/assets/..%2fprivate/a.png",
+		"/v1/proxy/" + strings.Replace(upstream.URL, "://", "://user@", 1) + "/assets/a.png",
+	} {
+		resp, err = http.Get(srv.URL + path)
+		if err != nil {
+			t.Fatalf("proxy request failed: %v", err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusForbidden {
+			t.Fatalf("expected 403 for %q, got %d", path, resp.StatusCode)
+		}
+	}
+
+	// 挂载在 ServeMux 下时使用百分号编码的目标 URL
+	mux := http.NewServeMux()
+	mux.Handle("/v1/", s)
+	muxSrv := httptest.NewServer(mux)
+	defer muxSrv.Close()
+	resp, err = http.Get(muxSrv.URL + "/v1/proxy/" + url.PathEscape(upstream.URL+"/assets/a.png"))
+	if err != nil {
+		t.Fatalf("proxy request failed: %v", err)
+	}
+	body, _ = io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || string(body) != "image:/assets/a.png?" {
+		t.Fatalf("escaped proxy response mismatch: %d %q", resp.StatusCode, body)
+	}
+
+	// 代理请求与路由变更并发进行
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for range 50 {
+			s.AddProxy("https://example.com/", nil)
+			s.RemoveProxy("https://example.com/")
+		}
+	}()
+	for range 10 {
+		resp, err = http.Get(proxied)
+		if err != nil {
+			t.Fatalf("proxy request failed: %v", err)
+		}
+		resp.Body.Close()
+	}
+	<-done
+
+	img, err := element.New[*element.Img](map[string]any{"src": src})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	if err := c.ProxyResources(img); err != nil {
+		t.Fatalf("ProxyResources failed: %v", err)
+	}
+	if img.Src != proxied {
+		t.Fatalf("ProxyResources src mismatch: %q", img.Src)
+	}
+}
+
+func TestServerProxyHost(t *testing.T) {
+	s := server.New(echoAdapter{})
+	s.AddProxy("https://cdn.example.com", nil)
+	srv := httptest.NewServer(s)
+	defer srv.Close()
+
+	for _, target := range []string{
+		"https://cdn.example.com.evil.test/secret",
+		"https://cdn.example.com@evil.test/secret",
+		"http://cdn.example.com/secret",
+	} {
+		resp, err := http.Get(srv.URL + "/v1/proxy/" + target)
+		if err != nil {
+			t.Fatalf("proxy request failed: %v", err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusForbidden {
+			t.Fatalf("expected 403 for %q, got %d", target, resp.StatusCode)
+		}
+	}
+}
+
+func TestServerStalledClient(t *testing.T) {
+	s := server.New(echoAdapter{}, server.WithWriteTimeout(500*time.Millisecond))
+	srv := httptest.NewServer(s)
+	defer srv.Close()
+
+	// 完成鉴权后不再读取任何信令的客户端
+	stalled, err := websocket.Dial("ws"+srv.URL[len("http"):]+"/v1/events", "", srv.URL)
+	if err != nil {
+		t.Fatalf("dial failed: %v", err)
+	}
+	defer stalled.Close()
+	if err := websocket.JSON.Send(stalled, &operation.Operation{Op: operation.OpcodeIdentify, Body: &operation.IdentifyBody{}}); err != nil {
+		t.Fatalf("send identify failed: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	ready := make(chan struct{}, 1)
+	events := make(chan *event.Event, 256)
+	ws := client.NewWebSocket(srv.URL, "", client.HandlerFunc(func(ctx context.Context, e *event.Event) {
+		events <- e
+	}), client.WithReadyHandler(func(ctx context.Context, body *operation.ReadyBody) { ready <- struct{}{} }))
+	go func() { _ = ws.Run(ctx) }()
+	select {
+	case <-ready:
+	case <-ctx.Done():
+		t.Fatalf("timed out waiting for READY")
+	}
+
+	content := strings.Repeat("x", 32<<10)
+	start := time.Now()
+	for range 100 {
+		if err := s.Dispatch(&event.Event{Type: event.EventTypeMessageCreated, Message: &message.Message{Content: content}}); err != nil {
+			t.Fatalf("Dispatch failed: %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("Dispatch blocked by stalled client for %v", elapsed)
+	}
+	for i := range 100 {
+		select {
+		case <-events:
+		case <-ctx.Done():
+			t.Fatalf("timed out waiting for event %d", i)
+		}
+	}
+}
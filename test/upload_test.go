@@ -0,0 +1,146 @@
+package testsuite
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/satori-protocol-go/satori-go/pkg/satori/client"
+	"github.com/satori-protocol-go/satori-go/pkg/satori/model/message/element"
+)
+
+func newUploadServer(t *testing.T, uploads map[string]string, sent *string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/upload.create":
+			if err := r.ParseMultipartForm(1 << 20); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			result := map[string]string{}
+			for field, headers := range r.MultipartForm.File {
+				f, _ := headers[0].Open()
+				data, _ := io.ReadAll(f)
+				_ = f.Close()
+				uploads[field] = headers[0].Header.Get("Content-Type") + ":" + string(data)
+				result[field] = "https://cdn.example.com/" + field
+			}
+			_ = json.NewEncoder(w).Encode(result)
+		case "/v1/message.create":
+			var body map[string]string
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			*sent = body["content"]
+			_, _ = io.WriteString(w, `[{"id":"m1","content":""}]`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestClientUploadCreate(t *testing.T) {
+	uploads := map[string]string{}
+	var sent string
+	server := newUploadServer(t, uploads, &sent)
+	c := client.New(server.URL).WithLogin("discord", "42")
+
+	urls, err := c.UploadCreate(context.Background(), map[string]*client.UploadFile{
+		"a": {Name: "a.txt", Data: strings.NewReader("hello")},
+	})
+	if err != nil {
+		t.Fatalf("UploadCreate failed: %v", err)
+	}
+	if urls["a"] != "https://cdn.example.com/a" {
+		t.Fatalf("UploadCreate result mismatch: %v", urls)
+	}
+	if !strings.HasPrefix(uploads["a"], "text/plain") || !strings.HasSuffix(uploads["a"], ":hello") {
+		t.Fatalf("UploadCreate payload mismatch: %q", uploads["a"])
+	}
+}
+
+func TestClientAutoUpload(t *testing.T) {
+	uploads := map[string]string{}
+	var sent string
+	server := newUploadServer(t, uploads, &sent)
+	root := t.TempDir()
+	c := client.New(server.URL, client.WithAutoUpload(root)).WithLogin("discord", "42")
+
+	path := filepath.Join(root, "pic.png")
+	if err := os.WriteFile(path, []byte("png-bytes"), 0o644); err != nil {
+		t.Fatalf("write temp file: %v", err)
+	}
+	content := `look <img src="file://` + path + `"/> and <audio src="https://example.com/a.mp3"/>`
+	if _, err := c.MessageCreate(context.Background(), "c1", content); err != nil {
+		t.Fatalf("MessageCreate failed: %v", err)
+	}
+	if len(uploads) != 1 || uploads["file0"] != "image/png:png-bytes" {
+		t.Fatalf("auto upload payload mismatch: %v", uploads)
+	}
+	want := `look <img src="https://cdn.example.com/file0"/> and <audio src="https://example.com/a.mp3"/>`
+	if sent != want {
+		t.Fatalf("auto upload content mismatch:\n got=%s\nwant=%s", sent, want)
+	}
+
+	sent = ""
+	if _, err := c.MessageCreate(context.Background(), "c1", "plain <b>text</b>"); err != nil {
+		t.Fatalf("MessageCreate failed: %v", err)
+	}
+	if sent != "plain <b>text</b>" {
+		t.Fatalf("content without local resources should be untouched: %s", sent)
+	}
+
+	// root 之外的文件与带有主机名的 file:// 地址不会被读取
+	outside := filepath.Join(t.TempDir(), "secret.txt")
+	if err := os.WriteFile(outside, []byte("secret"), 0o644); err != nil {
+		t.Fatalf("write temp file: %v", err)
+	}
+	link := filepath.Join(root, "link.txt")
+	if err := os.Symlink(outside, link); err != nil {
+		t.Fatalf("symlink: %v", err)
+	}
+	delete(uploads, "file0")
+	for _, src := range []string{"file://" + outside, "file://" + root + "/../" + filepath.Base(filepath.Dir(outside)) + "/secret.txt", "file://" + link, "file://relative/pic.png"} {
+		if _, err := c.MessageCreate(context.Background(), "c1", `<img src="`+src+`"/>`); err == nil {
+			t.Fatalf("MessageCreate should reject %q", src)
+		}
+	}
+	if len(uploads) != 0 {
+		t.Fatalf("files outside root should not be uploaded: %v", uploads)
+	}
+	c = client.New(server.URL, client.WithAutoUpload("")).WithLogin("discord", "42")
+	if _, err := c.MessageCreate(context.Background(), "c1", content); err == nil {
+		t.Fatalf("MessageCreate should reject local files without upload root")
+	}
+}
+
+func TestClientUploadResources(t *testing.T) {
+	uploads := map[string]string{}
+	var sent string
+	server := newUploadServer(t, uploads, &sent)
+	c := client.New(server.URL).WithLogin("discord", "42")
+
+	img, err := element.New[*element.Img](map[string]any{"src": "data:text/plain;base64,aGk="})
+	if err != nil {
+		t.Fatalf("new img: %v", err)
+	}
+	if err := c.UploadResources(context.Background(), img); err != nil {
+		t.Fatalf("UploadResources failed: %v", err)
+	}
+	if img.Src != "https://cdn.example.com/file0" {
+		t.Fatalf("UploadResources Src mismatch: %s", img.Src)
+	}
+	if src, _ := img.Get("src"); src != "https://cdn.example.com/file0" {
+		t.Fatalf("UploadResources attr mismatch: %v", src)
+	}
+	if uploads["file0"] != "text/plain:hi" {
+		t.Fatalf("UploadResources payload mismatch: %v", uploads)
+	}
+}
@@ -0,0 +1,53 @@
+package testsuite
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/satori-protocol-go/satori-go/pkg/satori/client"
+	"github.com/satori-protocol-go/satori-go/pkg/satori/model/event"
+)
+
+func TestWebhook(t *testing.T) {
+	var received []*event.Event
+	webhook := client.NewWebhook("secret", client.HandlerFunc(func(ctx context.Context, e *event.Event) {
+		received = append(received, e)
+	}))
+
+	tests := []struct {
+		name   string
+		method string
+		auth   string
+		opcode string
+		body   string
+		want   int
+	}{
+		{name: "event", method: http.MethodPost, auth: "Bearer secret", body: `{"sn":1,"type":"message-created","timestamp":1,"message":{"id":"m1","content":"hi"}}`, want: http.StatusOK},
+		{name: "wrong method", method: http.MethodGet, auth: "Bearer secret", want: http.StatusMethodNotAllowed},
+		{name: "wrong token", method: http.MethodPost, auth: "Bearer nope", body: `{}`, want: http.StatusUnauthorized},
+		{name: "malformed", method: http.MethodPost, auth: "Bearer secret", body: `{"sn":`, want: http.StatusBadRequest},
+		{name: "missing type", method: http.MethodPost, auth: "Bearer secret", body: `{"sn":2}`, want: http.StatusBadRequest},
+		{name: "meta opcode", method: http.MethodPost, auth: "Bearer secret", opcode: "4", body: `{"proxy_urls":[]}`, want: http.StatusOK},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, "/webhook", strings.NewReader(tc.body))
+			req.Header.Set("Authorization", tc.auth)
+			if tc.opcode != "" {
+				req.Header.Set(client.HeaderOpcode, tc.opcode)
+			}
+			rec := httptest.NewRecorder()
+			webhook.ServeHTTP(rec, req)
+			if rec.Code != tc.want {
+				t.Fatalf("status mismatch: got=%d want=%d body=%s", rec.Code, tc.want, rec.Body.String())
+			}
+		})
+	}
+
+	if len(received) != 1 || received[0].Type != event.EventTypeMessageCreated || received[0].Message.Content != "hi" {
+		t.Fatalf("received events mismatch: %+v", received)
+	}
+}
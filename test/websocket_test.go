@@ -0,0 +1,170 @@
+package testsuite
+
+import (
+	"context"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/satori-protocol-go/satori-go/pkg/satori/client"
+	"github.com/satori-protocol-go/satori-go/pkg/satori/model/event"
+	"github.com/satori-protocol-go/satori-go/pkg/satori/model/login"
+	"github.com/satori-protocol-go/satori-go/pkg/satori/model/operation"
+	"golang.org/x/net/websocket"
+)
+
+func TestWebSocketClientResume(t *testing.T) {
+	var (
+		mu         sync.Mutex
+		identifies []*operation.IdentifyBody
+		pings      int
+	)
+	server := httptest.NewServer(websocket.Handler(func(conn *websocket.Conn) {
+		var op operation.Operation
+		if err := websocket.JSON.Receive(conn, &op); err != nil {
+			return
+		}
+		body, _ := op.Body.(*operation.IdentifyBody)
+		mu.Lock()
+		identifies = append(identifies, body)
+		session := len(identifies)
+		mu.Unlock()
+
+		_ = websocket.JSON.Send(conn, &operation.Operation{Op: operation.OpcodeReady, Body: &operation.ReadyBody{
+			Logins: []*login.Login{{Sn: 1, Platform: "discord", Status: login.LoginStatusOnline}},
+		}})
+		sn := body.Sn + 1
+		_ = websocket.JSON.Send(conn, &operation.Operation{Op: operation.OpcodeEvent, Body: &event.Event{
+			Sn: sn, Type: event.EventTypeMessageCreated,
+		}})
+		if session == 1 {
+			// 第一次会话发送一个事件后断开，触发重连
+			return
+		}
+		for {
+			if err := websocket.JSON.Receive(conn, &op); err != nil {
+				return
+			}
+			if op.Op == operation.OpcodePing {
+				mu.Lock()
+				pings++
+				mu.Unlock()
+				_ = websocket.JSON.Send(conn, &operation.Operation{Op: operation.OpcodePong})
+			}
+		}
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	events := make(chan *event.Event, 4)
+	readies := 0
+	ws := client.NewWebSocket(server.URL, "secret", client.HandlerFunc(func(ctx context.Context, e *event.Event) {
+		events <- e
+	}),
+		client.WithSn(41),
+		client.WithPingInterval(20*time.Millisecond),
+		client.WithBackoff(10*time.Millisecond, 20*time.Millisecond),
+		client.WithReadyHandler(func(ctx context.Context, body *operation.ReadyBody) {
+			readies++
+		}),
+	)
+	result := make(chan error, 1)
+	go func() { result <- ws.Run(ctx) }()
+
+	for _, want := range []int64{42, 43} {
+		select {
+		case e := <-events:
+			if e.Sn != want || e.Type != event.EventTypeMessageCreated {
+				t.Fatalf("event mismatch: got sn=%d type=%s, want sn=%d", e.Sn, e.Type, want)
+			}
+		case <-ctx.Done():
+			t.Fatalf("timed out waiting for event %d", want)
+		}
+	}
+	time.Sleep(100 * time.Millisecond)
+	cancel()
+	if err := <-result; err != context.Canceled {
+		t.Fatalf("Run should return context.Canceled, got: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(identifies) < 2 || identifies[0].Token != "secret" || identifies[0].Sn != 41 || identifies[1].Sn != 42 {
+		t.Fatalf("identify mismatch: %+v", identifies)
+	}
+	if pings == 0 {
+		t.Fatalf("expected heartbeat pings")
+	}
+	if readies < 2 || ws.Sn() != 43 {
+		t.Fatalf("ready/sn mismatch: readies=%d sn=%d", readies, ws.Sn())
+	}
+}
+
+func TestWebSocketZeroPingInterval(t *testing.T) {
+	server := httptest.NewServer(websocket.Handler(func(conn *websocket.Conn) {
+		var op operation.Operation
+		if err := websocket.JSON.Receive(conn, &op); err != nil {
+			return
+		}
+		_ = websocket.JSON.Send(conn, &operation.Operation{Op: operation.OpcodeReady, Body: &operation.ReadyBody{}})
+		for websocket.JSON.Receive(conn, &op) == nil {
+		}
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	ready := make(chan struct{}, 1)
+	ws := client.NewWebSocket(server.URL, "", nil,
+		client.WithPingInterval(0),
+		client.WithReadyHandler(func(ctx context.Context, body *operation.ReadyBody) { ready <- struct{}{} }),
+	)
+	result := make(chan error, 1)
+	go func() { result <- ws.Run(ctx) }()
+
+	// 心跳间隔为 0 时使用默认值，而不是在心跳 goroutine 中 panic
+	select {
+	case <-ready:
+	case <-ctx.Done():
+		t.Fatalf("timed out waiting for READY")
+	}
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+	if err := <-result; err != context.Canceled {
+		t.Fatalf("Run should return context.Canceled, got: %v", err)
+	}
+}
+
+func TestWebSocketZeroBackoff(t *testing.T) {
+	server := httptest.NewServer(nil)
+	url := server.URL
+	server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	var (
+		mu       sync.Mutex
+		attempts int
+	)
+	ws := client.NewWebSocket(url, "", nil,
+		client.WithBackoff(0, 0),
+		client.WithErrorHandler(func(err error) {
+			mu.Lock()
+			attempts++
+			mu.Unlock()
+		}),
+	)
+	if err := ws.Run(ctx); err != context.DeadlineExceeded {
+		t.Fatalf("Run should return context.DeadlineExceeded, got: %v", err)
+	}
+
+	// 等待时间为 0 时使用默认值，而不是立即重连
+	mu.Lock()
+	defer mu.Unlock()
+	if attempts != 1 {
+		t.Fatalf("expected a single connection attempt, got %d", attempts)
+	}
+}